@@ -0,0 +1,286 @@
+// Package builder constructs GraphQL schemas programmatically, without writing the schema
+// definition language by hand.
+//
+// Type references, default values and directive usages are written in GraphQL syntax, e.g.
+// "[String!]!", `"unknown"` or `@deprecated(reason: "use name")`, so that they can be checked
+// by the same parser that is used for SDL schemas:
+//
+//	b := builder.New()
+//	b.Query().Field("user", "User", func(ctx context.Context, args struct{ ID graphql.ID }) (*User, error) {
+//		return loadUser(ctx, args.ID)
+//	}).Arg("id", "ID!")
+//	user := b.Object("User").Description("A registered user.")
+//	user.Field("id", "ID!", nil)
+//	user.Field("name", "String!", nil)
+//	schema, err := b.Schema(&struct{}{})
+package builder
+
+import (
+	"fmt"
+	"text/scanner"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/internal/common"
+	"github.com/graph-gophers/graphql-go/internal/schema"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// Builder collects type and directive definitions of a schema.
+type Builder struct {
+	entryPoints map[string]string
+	types       []namedType
+	typeByName  map[string]namedType
+	directives  []*DirectiveDefinition
+	errs        []error
+}
+
+type namedType interface {
+	name() string
+	build(c *buildContext) types.NamedType
+}
+
+type buildContext struct {
+	schema *types.Schema
+	errs   []error
+}
+
+func (c *buildContext) errorf(format string, a ...interface{}) {
+	c.errs = append(c.errs, fmt.Errorf(format, a...))
+}
+
+// New returns an empty Builder.
+func New() *Builder {
+	return &Builder{
+		entryPoints: make(map[string]string),
+		typeByName:  make(map[string]namedType),
+	}
+}
+
+// Query returns the object type named "Query", which is used as the query root operation type.
+func (b *Builder) Query() *Object {
+	return b.Object("Query")
+}
+
+// Mutation returns the object type named "Mutation", which is used as the mutation root
+// operation type.
+func (b *Builder) Mutation() *Object {
+	return b.Object("Mutation")
+}
+
+// Subscription returns the object type named "Subscription", which is used as the subscription
+// root operation type.
+func (b *Builder) Subscription() *Object {
+	return b.Object("Subscription")
+}
+
+// RootOperation uses the object type typeName as root operation type for the given operation
+// ("query", "mutation" or "subscription"). As with a schema definition in SDL, the default names
+// Query, Mutation and Subscription are not used once a root operation type is set explicitly.
+func (b *Builder) RootOperation(operation string, typeName string) *Builder {
+	b.entryPoints[operation] = typeName
+	return b
+}
+
+// Object returns the object type with the given name, defining it if necessary.
+func (b *Builder) Object(name string) *Object {
+	if o, ok := b.lookup(name).(*Object); ok {
+		return o
+	}
+	o := &Object{typeName: name, fields: newFieldSet()}
+	b.define(name, o)
+	return o
+}
+
+// Interface returns the interface type with the given name, defining it if necessary.
+func (b *Builder) Interface(name string) *Interface {
+	if i, ok := b.lookup(name).(*Interface); ok {
+		return i
+	}
+	i := &Interface{typeName: name, fields: newFieldSet()}
+	b.define(name, i)
+	return i
+}
+
+// Union returns the union type with the given name, defining it if necessary, and adds the given
+// member types to it.
+func (b *Builder) Union(name string, members ...string) *Union {
+	u, ok := b.lookup(name).(*Union)
+	if !ok {
+		u = &Union{typeName: name}
+		b.define(name, u)
+	}
+	u.members = append(u.members, members...)
+	return u
+}
+
+// Enum returns the enum type with the given name, defining it if necessary, and adds the given
+// values to it.
+func (b *Builder) Enum(name string, values ...string) *Enum {
+	e, ok := b.lookup(name).(*Enum)
+	if !ok {
+		e = &Enum{typeName: name}
+		b.define(name, e)
+	}
+	for _, v := range values {
+		e.Value(v)
+	}
+	return e
+}
+
+// Input returns the input object type with the given name, defining it if necessary.
+func (b *Builder) Input(name string) *Input {
+	if i, ok := b.lookup(name).(*Input); ok {
+		return i
+	}
+	i := &Input{typeName: name}
+	b.define(name, i)
+	return i
+}
+
+// Scalar returns the custom scalar type with the given name, defining it if necessary.
+func (b *Builder) Scalar(name string) *Scalar {
+	if s, ok := b.lookup(name).(*Scalar); ok {
+		return s
+	}
+	s := &Scalar{typeName: name}
+	b.define(name, s)
+	return s
+}
+
+// Directive defines a custom directive which may be used at the given locations, e.g.
+// "FIELD_DEFINITION".
+func (b *Builder) Directive(name string, locations ...string) *DirectiveDefinition {
+	d := &DirectiveDefinition{directiveName: name, locations: locations}
+	b.directives = append(b.directives, d)
+	return d
+}
+
+func (b *Builder) lookup(name string) namedType {
+	return b.typeByName[name]
+}
+
+func (b *Builder) define(name string, t namedType) {
+	if _, ok := b.typeByName[name]; ok {
+		b.errs = append(b.errs, fmt.Errorf("type %q is already defined with a different kind", name))
+		return
+	}
+	b.typeByName[name] = t
+	b.types = append(b.types, t)
+}
+
+// Build assembles the type system from the definitions collected so far. Every call returns a
+// new, independent types.Schema.
+func (b *Builder) Build() (*types.Schema, error) {
+	if len(b.errs) > 0 {
+		return nil, b.errs[0]
+	}
+
+	c := &buildContext{schema: schema.New()}
+	s := c.schema
+	for op, name := range b.entryPoints {
+		s.EntryPointNames[op] = name
+	}
+	for _, d := range b.directives {
+		s.Directives[d.directiveName] = d.build(c)
+	}
+	for _, bt := range b.types {
+		t := bt.build(c)
+		s.Types[bt.name()] = t
+		switch t := t.(type) {
+		case *types.ObjectTypeDefinition:
+			s.Objects = append(s.Objects, t)
+		case *types.Union:
+			s.Unions = append(s.Unions, t)
+		case *types.EnumTypeDefinition:
+			s.Enums = append(s.Enums, t)
+		}
+	}
+	if len(c.errs) > 0 {
+		return nil, c.errs[0]
+	}
+
+	if err := schema.Resolve(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Schema builds the type system and attaches the root resolver and the functions bound to fields
+// with Object.Field and Interface.Field. If root is nil, an empty root resolver is used, so all
+// fields of the root operation types must be bound to functions.
+func (b *Builder) Schema(root interface{}, opts ...graphql.SchemaOpt) (*graphql.Schema, error) {
+	s, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var funcOpts []graphql.SchemaOpt
+	for _, bt := range b.types {
+		var fs *fieldSet
+		switch t := bt.(type) {
+		case *Object:
+			fs = t.fields
+		case *Interface:
+			fs = t.fields
+		default:
+			continue
+		}
+		for _, f := range fs.fields {
+			if f.resolver != nil {
+				funcOpts = append(funcOpts, graphql.ResolverFunc(bt.name()+"."+f.fieldName, f.resolver))
+			}
+		}
+	}
+
+	if root == nil {
+		root = &struct{}{}
+	}
+	return graphql.NewSchema(s, root, append(funcOpts, opts...)...)
+}
+
+// parse runs f on a lexer for src and reports a syntax error if f fails or does not consume all
+// of src.
+func parse(src string, f func(l *common.Lexer)) error {
+	l := common.NewLexer(src, false)
+	if err := l.CatchSyntaxError(func() {
+		l.ConsumeWhitespace()
+		f(l)
+		if l.Peek() != scanner.EOF {
+			l.SyntaxError(fmt.Sprintf("unexpected input after the end of %q", src))
+		}
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *buildContext) parseType(typ string, owner string) types.Type {
+	var t types.Type
+	if err := parse(typ, func(l *common.Lexer) { t = common.ParseType(l) }); err != nil {
+		c.errorf("invalid type %q of %s: %s", typ, owner, err)
+		return &types.TypeName{Ident: types.Ident{Name: typ}}
+	}
+	return t
+}
+
+func (c *buildContext) parseValue(value string, owner string) types.Value {
+	var v types.Value
+	if err := parse(value, func(l *common.Lexer) { v = common.ParseLiteral(l, true) }); err != nil {
+		c.errorf("invalid value %q of %s: %s", value, owner, err)
+		return nil
+	}
+	return v
+}
+
+func (c *buildContext) parseDirectives(directives []string, owner string) types.DirectiveList {
+	var l types.DirectiveList
+	for _, d := range directives {
+		var parsed types.DirectiveList
+		if err := parse(d, func(lex *common.Lexer) { parsed = common.ParseDirectives(lex) }); err != nil {
+			c.errorf("invalid directive %q of %s: %s", d, owner, err)
+			continue
+		}
+		l = append(l, parsed...)
+	}
+	return l
+}
@@ -0,0 +1,213 @@
+package builder_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/builder"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+type user struct {
+	ID   graphql.ID
+	Name string
+	Role string
+}
+
+func (u *user) ToUser() (*user, bool) { return u, true }
+
+var users = []*user{
+	{ID: "1", Name: "Alice", Role: "ADMIN"},
+	{ID: "2", Name: "Bob", Role: "MEMBER"},
+}
+
+func newBuilder() *builder.Builder {
+	b := builder.New()
+	b.Directive("audit", "FIELD_DEFINITION").Arg("level", "Int").Default("1")
+	b.Enum("Role", "ADMIN", "MEMBER").Description("The role of a user.")
+	b.Enum("Role").Value("GUEST").Deprecated("guests are no longer supported")
+
+	b.Interface("Node").Field("id", "ID!", nil)
+	u := b.Object("User").Implements("Node").Description("A registered user.")
+	u.Field("id", "ID!", nil)
+	u.Field("name", "String!", nil).Directive("@audit")
+	u.Field("role", "Role!", nil)
+	u.Field("greeting", "String!", func(u *user, args struct{ Prefix string }) string {
+		return args.Prefix + " " + u.Name
+	}).ArgValue("prefix", "String").Default(`"Hello"`)
+	b.Union("SearchResult", "User")
+
+	b.Input("UserFilter").Field("role", "Role")
+
+	q := b.Query()
+	q.Field("user", "User", func(ctx context.Context, args struct{ ID graphql.ID }) *user {
+		for _, u := range users {
+			if u.ID == args.ID {
+				return u
+			}
+		}
+		return nil
+	}).Arg("id", "ID!")
+	q.Field("users", "[User!]!", func(args struct{ Filter *struct{ Role *string } }) []*user {
+		var l []*user
+		for _, u := range users {
+			if args.Filter == nil || args.Filter.Role == nil || *args.Filter.Role == u.Role {
+				l = append(l, u)
+			}
+		}
+		return l
+	}).Arg("filter", "UserFilter")
+	q.Field("search", "[SearchResult!]!", func() []*user { return users })
+	return b
+}
+
+func TestBuild(t *testing.T) {
+	s, err := newBuilder().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, ok := s.Types["User"].(*types.ObjectTypeDefinition)
+	if !ok {
+		t.Fatalf("type %q not found", "User")
+	}
+	if want, have := "A registered user.", u.Desc; want != have {
+		t.Errorf("invalid description: want %q, have %q", want, have)
+	}
+	if len(u.Interfaces) != 1 || u.Interfaces[0].Name != "Node" {
+		t.Errorf("User must implement Node, have %v", u.Interfaces)
+	}
+	if _, ok := u.Fields.Get("role").Type.(*types.NonNull).OfType.(*types.EnumTypeDefinition); !ok {
+		t.Errorf("field User.role must be resolved to an enum type")
+	}
+	if d := u.Fields.Get("name").Directives.Get("audit"); d == nil {
+		t.Errorf("field User.name is missing the @audit directive")
+	}
+	if want, have := "Query", s.EntryPoints["query"].TypeName(); want != have {
+		t.Errorf("invalid query type: want %q, have %q", want, have)
+	}
+}
+
+func TestBuild_errors(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		build func(b *builder.Builder)
+		err   string
+	}{
+		{
+			name: "invalid type reference",
+			build: func(b *builder.Builder) {
+				b.Query().Field("users", "[User!", nil)
+			},
+			err: `invalid type "[User!" of field Query.users`,
+		},
+		{
+			name: "unknown type",
+			build: func(b *builder.Builder) {
+				b.Query().Field("user", "User", nil)
+			},
+			err: `Unknown type "User".`,
+		},
+		{
+			name: "redefined with different kind",
+			build: func(b *builder.Builder) {
+				b.Query().Field("role", "Role", nil)
+				b.Object("Role")
+				b.Enum("Role", "ADMIN")
+			},
+			err: `type "Role" is already defined with a different kind`,
+		},
+		{
+			name: "invalid directive",
+			build: func(b *builder.Builder) {
+				b.Query().Field("name", "String", nil).Directive("deprecated")
+			},
+			err: `invalid directive "deprecated" of field Query.name`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			b := builder.New()
+			test.build(b)
+			_, err := b.Build()
+			if err == nil {
+				t.Fatalf("want error containing %q, have <nil>", test.err)
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("want error containing %q, have %q", test.err, err)
+			}
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	s, err := newBuilder().Schema(nil, graphql.UseFieldResolvers())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: s,
+			Query: `
+				{
+					user(id: "1") {
+						name
+						role
+						greeting
+						hi: greeting(prefix: "Hi")
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"user": {
+						"name": "Alice",
+						"role": "ADMIN",
+						"greeting": "Hello Alice",
+						"hi": "Hi Alice"
+					}
+				}
+			`,
+		},
+		{
+			Schema: s,
+			Query: `
+				{
+					users(filter: {role: MEMBER}) {
+						id
+					}
+					search {
+						__typename
+						... on Node {
+							id
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"users": [{"id": "2"}],
+					"search": [
+						{"__typename": "User", "id": "1"},
+						{"__typename": "User", "id": "2"}
+					]
+				}
+			`,
+		},
+	})
+}
+
+func TestSchema_invalidResolver(t *testing.T) {
+	b := builder.New()
+	b.Query().Field("hello", "String!", func(args struct{ Name string }) string { return args.Name })
+	_, err := b.Schema(nil)
+	if err == nil {
+		t.Fatal("want error, have <nil>")
+	}
+	if want := "used by function bound to Query.hello"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("want error containing %q, have %q", want, err)
+	}
+}
@@ -0,0 +1,423 @@
+package builder
+
+import (
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// Object is the builder of an object type.
+type Object struct {
+	fields     *fieldSet
+	typeName   string
+	desc       string
+	interfaces []string
+	directives []string
+}
+
+// Description sets the description of the object type.
+func (o *Object) Description(desc string) *Object {
+	o.desc = desc
+	return o
+}
+
+// Implements declares that the object type implements the given interfaces.
+func (o *Object) Implements(interfaces ...string) *Object {
+	o.interfaces = append(o.interfaces, interfaces...)
+	return o
+}
+
+// Field returns the field with the given name, defining it with the type typ (e.g. "[User!]!") if
+// necessary. If resolver is not nil, it is a Go function resolving the field, see
+// graphql.ResolverFunc for its signature. Otherwise the field is resolved by a method or struct
+// field of the parent resolver. Calling Field for an existing field replaces its type and resolver.
+func (o *Object) Field(name string, typ string, resolver interface{}) *Field {
+	return o.fields.field(name, typ, resolver)
+}
+
+// Directive applies a directive, e.g. `@key(fields: "id")`, to the object type.
+func (o *Object) Directive(directive string) *Object {
+	o.directives = append(o.directives, directive)
+	return o
+}
+
+func (o *Object) name() string { return o.typeName }
+
+func (o *Object) build(c *buildContext) types.NamedType {
+	return &types.ObjectTypeDefinition{
+		Name:           o.typeName,
+		Desc:           o.desc,
+		Fields:         o.fields.build(c, o.typeName),
+		Directives:     c.parseDirectives(o.directives, "type "+o.typeName),
+		InterfaceNames: append([]string(nil), o.interfaces...),
+	}
+}
+
+// Interface is the builder of an interface type.
+type Interface struct {
+	fields     *fieldSet
+	typeName   string
+	desc       string
+	directives []string
+}
+
+// Description sets the description of the interface type.
+func (i *Interface) Description(desc string) *Interface {
+	i.desc = desc
+	return i
+}
+
+// Field returns the field with the given name, defining it if necessary. See Object.Field.
+func (i *Interface) Field(name string, typ string, resolver interface{}) *Field {
+	return i.fields.field(name, typ, resolver)
+}
+
+// Directive applies a directive to the interface type.
+func (i *Interface) Directive(directive string) *Interface {
+	i.directives = append(i.directives, directive)
+	return i
+}
+
+func (i *Interface) name() string { return i.typeName }
+
+func (i *Interface) build(c *buildContext) types.NamedType {
+	return &types.InterfaceTypeDefinition{
+		Name:       i.typeName,
+		Desc:       i.desc,
+		Fields:     i.fields.build(c, i.typeName),
+		Directives: c.parseDirectives(i.directives, "interface "+i.typeName),
+	}
+}
+
+type fieldSet struct {
+	fields []*Field
+	byName map[string]*Field
+}
+
+func newFieldSet() *fieldSet {
+	return &fieldSet{byName: make(map[string]*Field)}
+}
+
+func (fs *fieldSet) field(name string, typ string, resolver interface{}) *Field {
+	f, ok := fs.byName[name]
+	if !ok {
+		f = &Field{fieldName: name}
+		fs.byName[name] = f
+		fs.fields = append(fs.fields, f)
+	}
+	f.typ = typ
+	f.resolver = resolver
+	return f
+}
+
+func (fs *fieldSet) build(c *buildContext, typeName string) types.FieldsDefinition {
+	fields := make(types.FieldsDefinition, len(fs.fields))
+	for i, f := range fs.fields {
+		fields[i] = f.build(c, typeName)
+	}
+	return fields
+}
+
+// Field is the builder of a field of an object or interface type.
+type Field struct {
+	fieldName  string
+	typ        string
+	desc       string
+	resolver   interface{}
+	args       []*InputValue
+	directives []string
+}
+
+// Description sets the description of the field.
+func (f *Field) Description(desc string) *Field {
+	f.desc = desc
+	return f
+}
+
+// Arg adds an argument with the type typ to the field. The argument can be refined with
+// ArgValue.
+func (f *Field) Arg(name string, typ string) *Field {
+	f.ArgValue(name, typ)
+	return f
+}
+
+// ArgValue adds an argument with the type typ to the field and returns it.
+func (f *Field) ArgValue(name string, typ string) *InputValue {
+	v := &InputValue{valueName: name, typ: typ}
+	f.args = append(f.args, v)
+	return v
+}
+
+// Deprecated marks the field as deprecated for the given reason.
+func (f *Field) Deprecated(reason string) *Field {
+	return f.Directive(deprecated(reason))
+}
+
+// Directive applies a directive to the field.
+func (f *Field) Directive(directive string) *Field {
+	f.directives = append(f.directives, directive)
+	return f
+}
+
+func (f *Field) build(c *buildContext, typeName string) *types.FieldDefinition {
+	owner := "field " + typeName + "." + f.fieldName
+	return &types.FieldDefinition{
+		Name:       f.fieldName,
+		Desc:       f.desc,
+		Type:       c.parseType(f.typ, owner),
+		Arguments:  buildInputValues(c, f.args, owner),
+		Directives: c.parseDirectives(f.directives, owner),
+	}
+}
+
+// InputValue is the builder of an argument, an input object field or a directive argument.
+type InputValue struct {
+	valueName  string
+	typ        string
+	desc       string
+	defaultVal string
+	directives []string
+}
+
+// Description sets the description of the input value.
+func (v *InputValue) Description(desc string) *InputValue {
+	v.desc = desc
+	return v
+}
+
+// Default sets the default value of the input value as a GraphQL literal, e.g. `"unknown"`,
+// `10` or `{limit: 10}`.
+func (v *InputValue) Default(value string) *InputValue {
+	v.defaultVal = value
+	return v
+}
+
+// Directive applies a directive to the input value.
+func (v *InputValue) Directive(directive string) *InputValue {
+	v.directives = append(v.directives, directive)
+	return v
+}
+
+func buildInputValues(c *buildContext, values []*InputValue, owner string) types.ArgumentsDefinition {
+	var l types.ArgumentsDefinition
+	for _, v := range values {
+		vOwner := owner + "(" + v.valueName + ":)"
+		iv := &types.InputValueDefinition{
+			Name:       types.Ident{Name: v.valueName},
+			Desc:       v.desc,
+			Type:       c.parseType(v.typ, vOwner),
+			Directives: c.parseDirectives(v.directives, vOwner),
+		}
+		if v.defaultVal != "" {
+			iv.Default = c.parseValue(v.defaultVal, vOwner)
+		}
+		l = append(l, iv)
+	}
+	return l
+}
+
+// Union is the builder of a union type.
+type Union struct {
+	typeName   string
+	desc       string
+	members    []string
+	directives []string
+}
+
+// Description sets the description of the union type.
+func (u *Union) Description(desc string) *Union {
+	u.desc = desc
+	return u
+}
+
+// Directive applies a directive to the union type.
+func (u *Union) Directive(directive string) *Union {
+	u.directives = append(u.directives, directive)
+	return u
+}
+
+func (u *Union) name() string { return u.typeName }
+
+func (u *Union) build(c *buildContext) types.NamedType {
+	return &types.Union{
+		Name:       u.typeName,
+		Desc:       u.desc,
+		TypeNames:  append([]string(nil), u.members...),
+		Directives: c.parseDirectives(u.directives, "union "+u.typeName),
+	}
+}
+
+// Enum is the builder of an enum type.
+type Enum struct {
+	typeName   string
+	desc       string
+	values     []*EnumValue
+	directives []string
+}
+
+// Description sets the description of the enum type.
+func (e *Enum) Description(desc string) *Enum {
+	e.desc = desc
+	return e
+}
+
+// Value returns the enum value with the given name, defining it if necessary.
+func (e *Enum) Value(name string) *EnumValue {
+	for _, v := range e.values {
+		if v.valueName == name {
+			return v
+		}
+	}
+	v := &EnumValue{valueName: name}
+	e.values = append(e.values, v)
+	return v
+}
+
+// Directive applies a directive to the enum type.
+func (e *Enum) Directive(directive string) *Enum {
+	e.directives = append(e.directives, directive)
+	return e
+}
+
+func (e *Enum) name() string { return e.typeName }
+
+func (e *Enum) build(c *buildContext) types.NamedType {
+	t := &types.EnumTypeDefinition{
+		Name:       e.typeName,
+		Desc:       e.desc,
+		Directives: c.parseDirectives(e.directives, "enum "+e.typeName),
+	}
+	for _, v := range e.values {
+		t.EnumValuesDefinition = append(t.EnumValuesDefinition, &types.EnumValueDefinition{
+			EnumValue:  v.valueName,
+			Desc:       v.desc,
+			Directives: c.parseDirectives(v.directives, "enum value "+e.typeName+"."+v.valueName),
+		})
+	}
+	return t
+}
+
+// EnumValue is the builder of a value of an enum type.
+type EnumValue struct {
+	valueName  string
+	desc       string
+	directives []string
+}
+
+// Description sets the description of the enum value.
+func (v *EnumValue) Description(desc string) *EnumValue {
+	v.desc = desc
+	return v
+}
+
+// Deprecated marks the enum value as deprecated for the given reason.
+func (v *EnumValue) Deprecated(reason string) *EnumValue {
+	return v.Directive(deprecated(reason))
+}
+
+// Directive applies a directive to the enum value.
+func (v *EnumValue) Directive(directive string) *EnumValue {
+	v.directives = append(v.directives, directive)
+	return v
+}
+
+// Input is the builder of an input object type.
+type Input struct {
+	typeName   string
+	desc       string
+	fields     []*InputValue
+	directives []string
+}
+
+// Description sets the description of the input object type.
+func (i *Input) Description(desc string) *Input {
+	i.desc = desc
+	return i
+}
+
+// Field adds a field with the type typ to the input object type and returns it.
+func (i *Input) Field(name string, typ string) *InputValue {
+	v := &InputValue{valueName: name, typ: typ}
+	i.fields = append(i.fields, v)
+	return v
+}
+
+// Directive applies a directive to the input object type.
+func (i *Input) Directive(directive string) *Input {
+	i.directives = append(i.directives, directive)
+	return i
+}
+
+func (i *Input) name() string { return i.typeName }
+
+func (i *Input) build(c *buildContext) types.NamedType {
+	return &types.InputObject{
+		Name:       i.typeName,
+		Desc:       i.desc,
+		Values:     buildInputValues(c, i.fields, "input "+i.typeName),
+		Directives: c.parseDirectives(i.directives, "input "+i.typeName),
+	}
+}
+
+// Scalar is the builder of a custom scalar type.
+type Scalar struct {
+	typeName   string
+	desc       string
+	directives []string
+}
+
+// Description sets the description of the scalar type.
+func (s *Scalar) Description(desc string) *Scalar {
+	s.desc = desc
+	return s
+}
+
+// Directive applies a directive to the scalar type.
+func (s *Scalar) Directive(directive string) *Scalar {
+	s.directives = append(s.directives, directive)
+	return s
+}
+
+func (s *Scalar) name() string { return s.typeName }
+
+func (s *Scalar) build(c *buildContext) types.NamedType {
+	return &types.ScalarTypeDefinition{
+		Name:       s.typeName,
+		Desc:       s.desc,
+		Directives: c.parseDirectives(s.directives, "scalar "+s.typeName),
+	}
+}
+
+// DirectiveDefinition is the builder of a custom directive.
+type DirectiveDefinition struct {
+	directiveName string
+	desc          string
+	locations     []string
+	args          []*InputValue
+}
+
+// Description sets the description of the directive.
+func (d *DirectiveDefinition) Description(desc string) *DirectiveDefinition {
+	d.desc = desc
+	return d
+}
+
+// Arg adds an argument with the type typ to the directive and returns it.
+func (d *DirectiveDefinition) Arg(name string, typ string) *InputValue {
+	v := &InputValue{valueName: name, typ: typ}
+	d.args = append(d.args, v)
+	return v
+}
+
+func (d *DirectiveDefinition) build(c *buildContext) *types.DirectiveDefinition {
+	return &types.DirectiveDefinition{
+		Name:      d.directiveName,
+		Desc:      d.desc,
+		Locations: append([]string(nil), d.locations...),
+		Arguments: buildInputValues(c, d.args, "directive @"+d.directiveName),
+	}
+}
+
+func deprecated(reason string) string {
+	return "@deprecated(reason: " + strconv.Quote(reason) + ")"
+}
@@ -0,0 +1,24 @@
+package graphql
+
+// ConstraintDirective declares the @constraint directive, which restricts the values of arguments
+// and input fields. Add it to a schema which uses the directive:
+//
+//	schema := graphql.MustParseSchema(graphql.ConstraintDirective+schemaString, resolver)
+//
+// minLength and maxLength limit the number of characters of a string or of elements of a list,
+// pattern is a regular expression a string must match, min and max limit numbers and format is
+// one of "email", "uri", "uuid", "date", "date-time", "ipv4" or "ipv6". The constraints other
+// than the length of a list apply to each of its elements. The values are checked before the
+// resolver is called. This includes resolvers which are maps or DynamicObjects. A violation is
+// reported as an error with the code CONSTRAINT_VIOLATION and the path of the invalid value, e.g.
+// "input.tags[1]", in its extensions.
+const ConstraintDirective = `
+directive @constraint(
+	minLength: Int
+	maxLength: Int
+	pattern: String
+	min: Float
+	max: Float
+	format: String
+) on ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION
+`
@@ -0,0 +1,150 @@
+package graphql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+)
+
+type signupInput struct {
+	Email string
+	Name  string
+	Tags  *[]string
+}
+
+type signupResolver struct{}
+
+func (*signupResolver) Signup(args struct {
+	Input signupInput
+	Age   int32
+}) string {
+	return args.Input.Name
+}
+
+var constraintSchema = graphql.MustParseSchema(graphql.ConstraintDirective+`
+	input SignupInput {
+		email: String! @constraint(format: "email")
+		name: String! @constraint(minLength: 2, maxLength: 5, pattern: "^[a-z]+$")
+		tags: [String!] @constraint(maxLength: 2, minLength: 1, pattern: "^#")
+	}
+	type Query {
+		signup(input: SignupInput!, age: Int! @constraint(min: 18, max: 150)): String!
+	}
+`, &signupResolver{})
+
+func TestConstraint(t *testing.T) {
+	violation := func(message, argument string, line, column int) *gqlerrors.QueryError {
+		return &gqlerrors.QueryError{
+			Message:   message,
+			Locations: []gqlerrors.Location{{Line: line, Column: column}},
+			Extensions: map[string]interface{}{
+				"code":     "CONSTRAINT_VIOLATION",
+				"argument": argument,
+			},
+		}
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: constraintSchema,
+			Query: `
+				{
+					signup(input: {email: "a@example.com", name: "abc", tags: ["#x"]}, age: 18)
+				}
+			`,
+			ExpectedResult: `
+				{
+					"signup": "abc"
+				}
+			`,
+		},
+		{
+			Schema: constraintSchema,
+			Query: `
+				{
+					signup(input: {email: "a@example.com", name: "abc"}, age: 17)
+				}
+			`,
+			ExpectedResult: `{}`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				violation(`invalid value for "age": must be at least 18`, "age", 3, 64),
+			},
+		},
+		{
+			Schema: constraintSchema,
+			Query: `
+				{
+					signup(input: {email: "a", name: "abc"}, age: 20)
+				}
+			`,
+			ExpectedResult: `{}`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				violation(`invalid value for "input.email": must be in the format "email"`, "input.email", 3, 20),
+			},
+		},
+		{
+			Schema: constraintSchema,
+			Query: `
+				{
+					signup(input: {email: "a@example.com", name: "abcdef"}, age: 20)
+				}
+			`,
+			ExpectedResult: `{}`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				violation(`invalid value for "input.name": must have at most 5 characters`, "input.name", 3, 20),
+			},
+		},
+		{
+			Schema: constraintSchema,
+			Query: `
+				{
+					signup(input: {email: "a@example.com", name: "ABC"}, age: 20)
+				}
+			`,
+			ExpectedResult: `{}`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				violation(`invalid value for "input.name": must match the pattern "^[a-z]+$"`, "input.name", 3, 20),
+			},
+		},
+		{
+			Schema: constraintSchema,
+			Query: `
+				{
+					signup(input: {email: "a@example.com", name: "abc", tags: []}, age: 20)
+				}
+			`,
+			ExpectedResult: `{}`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				violation(`invalid value for "input.tags": must have at least 1 elements`, "input.tags", 3, 20),
+			},
+		},
+		{
+			Schema: constraintSchema,
+			Query: `
+				query($input: SignupInput!) {
+					signup(input: $input, age: 20)
+				}
+			`,
+			Variables: map[string]interface{}{
+				"input": map[string]interface{}{"email": "a@example.com", "name": "abc", "tags": []interface{}{"#a", "b"}},
+			},
+			ExpectedResult: `{}`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				violation(`invalid value for "input.tags[1]": must match the pattern "^#"`, "input.tags[1]", 3, 20),
+			},
+		},
+	})
+
+	_, err := graphql.ParseSchema(graphql.ConstraintDirective+`
+		type Query {
+			signup(age: Int! @constraint(format: "phone")): String!
+		}
+	`, &signupResolver{})
+	want := `@constraint argument "format": unknown format "phone"`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("want error containing %q, got %v", want, err)
+	}
+}
@@ -8,6 +8,61 @@ type Unmarshaler interface {
 	// UnmarshalGraphQL is the custom unmarshaler for the implementing type
 	//
 	// This function will be called whenever you use the
-	// custom GraphQL scalar type as an input
+	// custom GraphQL scalar type as an input. Numbers in
+	// variables are passed as decoded by the caller of the
+	// schema, e.g. as float64 by relay.Handler, or as
+	// json.Number if its UseNumber field is set.
 	UnmarshalGraphQL(input interface{}) error
 }
+
+// LiteralUnmarshaler is an optional interface of an Unmarshaler which receives
+// the literals of a query or of a default value as written, e.g. to decode
+// integers which do not fit into an int64 without loss of precision.
+type LiteralUnmarshaler interface {
+	// UnmarshalGraphQLLiteral is called instead of UnmarshalGraphQL for
+	// a literal Int, Float, String, Boolean or Enum value, which is given
+	// by kind. The text of a String is unquoted, all other literals are
+	// passed as written in the query.
+	UnmarshalGraphQLLiteral(kind string, text string) error
+}
+
+// Marshaler defines the output api of Go types mapped to custom GraphQL scalar types
+type Marshaler interface {
+	// ImplementsGraphQLType maps the implementing custom Go type
+	// to the GraphQL scalar type in the schema.
+	ImplementsGraphQLType(name string) bool
+	// MarshalGraphQL is the custom marshaler for the implementing type
+	//
+	// This function will be called whenever a resolver returns the
+	// custom GraphQL scalar type as an output. The returned value is
+	// encoded as JSON. If an error is returned, the field resolves to
+	// null and the error is added to the response.
+	MarshalGraphQL() (interface{}, error)
+}
+
+// EnumMarshaler is implemented by Go types with an integer kind that represent a GraphQL enum,
+// together with EnumUnmarshaler. Each value of the enum must be unmarshaled and marshaled back to
+// the same name, which is checked when the schema is parsed.
+type EnumMarshaler interface {
+	// MarshalEnum returns the name of the enum value.
+	MarshalEnum() (string, error)
+}
+
+// EnumUnmarshaler is the input counterpart of EnumMarshaler.
+type EnumUnmarshaler interface {
+	// UnmarshalEnum sets the receiver to the enum value with the given name.
+	UnmarshalEnum(name string) error
+}
+
+// Optional marks a struct with the fields Value and Set bool which receives an input value of the
+// type of Value and records in Set whether the value was given at all, even if it was null:
+//
+//	Name struct {
+//		decode.Optional
+//		Value *string
+//		Set   bool
+//	}
+type Optional struct{}
+
+// OptionalInput is implemented by the structs embedding Optional.
+func (Optional) OptionalInput() {}
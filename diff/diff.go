@@ -0,0 +1,493 @@
+// Package diff compares two GraphQL schemas and classifies the changes between them by their
+// impact on existing clients.
+package diff
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// Criticality describes the impact of a change on existing clients.
+type Criticality string
+
+const (
+	// Breaking changes make previously valid operations invalid or change the shape of their
+	// results in incompatible ways.
+	Breaking Criticality = "BREAKING"
+	// Dangerous changes keep operations valid but may change the behavior of clients, e.g. by
+	// returning enum values or types they do not know about.
+	Dangerous Criticality = "DANGEROUS"
+	// Safe changes do not affect existing clients.
+	Safe Criticality = "SAFE"
+)
+
+// ChangeType identifies the kind of a change.
+type ChangeType string
+
+const (
+	TypeAdded                ChangeType = "TYPE_ADDED"
+	TypeRemoved              ChangeType = "TYPE_REMOVED"
+	TypeKindChanged          ChangeType = "TYPE_KIND_CHANGED"
+	TypeDescriptionChanged   ChangeType = "TYPE_DESCRIPTION_CHANGED"
+	RootOperationChanged     ChangeType = "ROOT_OPERATION_CHANGED"
+	FieldAdded               ChangeType = "FIELD_ADDED"
+	FieldRemoved             ChangeType = "FIELD_REMOVED"
+	FieldTypeChanged         ChangeType = "FIELD_TYPE_CHANGED"
+	FieldDescriptionChanged  ChangeType = "FIELD_DESCRIPTION_CHANGED"
+	FieldDeprecationAdded    ChangeType = "FIELD_DEPRECATION_ADDED"
+	FieldDeprecationRemoved  ChangeType = "FIELD_DEPRECATION_REMOVED"
+	ArgAdded                 ChangeType = "ARG_ADDED"
+	ArgRemoved               ChangeType = "ARG_REMOVED"
+	ArgTypeChanged           ChangeType = "ARG_TYPE_CHANGED"
+	ArgDefaultChanged        ChangeType = "ARG_DEFAULT_CHANGED"
+	ArgDescriptionChanged    ChangeType = "ARG_DESCRIPTION_CHANGED"
+	InterfaceAdded           ChangeType = "INTERFACE_ADDED"
+	InterfaceRemoved         ChangeType = "INTERFACE_REMOVED"
+	UnionMemberAdded         ChangeType = "UNION_MEMBER_ADDED"
+	UnionMemberRemoved       ChangeType = "UNION_MEMBER_REMOVED"
+	EnumValueAdded           ChangeType = "ENUM_VALUE_ADDED"
+	EnumValueRemoved         ChangeType = "ENUM_VALUE_REMOVED"
+	EnumValueDeprecated      ChangeType = "ENUM_VALUE_DEPRECATION_ADDED"
+	EnumValueUndeprecated    ChangeType = "ENUM_VALUE_DEPRECATION_REMOVED"
+	InputFieldAdded          ChangeType = "INPUT_FIELD_ADDED"
+	InputFieldRemoved        ChangeType = "INPUT_FIELD_REMOVED"
+	InputFieldTypeChanged    ChangeType = "INPUT_FIELD_TYPE_CHANGED"
+	InputFieldDefaultChanged ChangeType = "INPUT_FIELD_DEFAULT_CHANGED"
+	DirectiveAdded           ChangeType = "DIRECTIVE_ADDED"
+	DirectiveRemoved         ChangeType = "DIRECTIVE_REMOVED"
+	DirectiveLocationAdded   ChangeType = "DIRECTIVE_LOCATION_ADDED"
+	DirectiveLocationRemoved ChangeType = "DIRECTIVE_LOCATION_REMOVED"
+	DirectiveArgAdded        ChangeType = "DIRECTIVE_ARG_ADDED"
+	DirectiveArgRemoved      ChangeType = "DIRECTIVE_ARG_REMOVED"
+	DirectiveArgTypeChanged  ChangeType = "DIRECTIVE_ARG_TYPE_CHANGED"
+)
+
+// Change is a single difference between two schemas.
+type Change struct {
+	Type        ChangeType  `json:"type"`
+	Criticality Criticality `json:"criticality"`
+	// Path is the schema coordinate of the changed element, e.g. "User.name",
+	// "Query.users(first:)", "Role.ADMIN" or "@auth(role:)".
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s: %s", c.Criticality, c.Path, c.Message)
+}
+
+// Compare returns the changes needed to turn oldSchema into newSchema, ordered by the path of
+// the changed element.
+func Compare(oldSchema, newSchema *types.Schema) []Change {
+	c := &comparison{}
+	c.compareRootOperations(oldSchema, newSchema)
+	c.compareTypes(oldSchema, newSchema)
+	c.compareDirectives(oldSchema, newSchema)
+	sort.SliceStable(c.changes, func(i, j int) bool { return c.changes[i].Path < c.changes[j].Path })
+	return c.changes
+}
+
+// HasBreaking reports whether any of the changes is breaking.
+func HasBreaking(changes []Change) bool {
+	return Filter(changes, Breaking) != nil
+}
+
+// Filter returns the changes of the given criticality.
+func Filter(changes []Change, criticality Criticality) []Change {
+	var l []Change
+	for _, c := range changes {
+		if c.Criticality == criticality {
+			l = append(l, c)
+		}
+	}
+	return l
+}
+
+type comparison struct {
+	changes []Change
+}
+
+func (c *comparison) add(typ ChangeType, criticality Criticality, path string, format string, a ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Type:        typ,
+		Criticality: criticality,
+		Path:        path,
+		Message:     fmt.Sprintf(format, a...),
+	})
+}
+
+func (c *comparison) compareRootOperations(oldSchema, newSchema *types.Schema) {
+	for _, op := range []string{"query", "mutation", "subscription"} {
+		oldType, newType := oldSchema.EntryPoints[op], newSchema.EntryPoints[op]
+		switch {
+		case oldType == nil && newType == nil:
+		case oldType == nil:
+			c.add(RootOperationChanged, Safe, newType.TypeName(), "Type %q was set as %s root type.", newType.TypeName(), op)
+		case newType == nil:
+			c.add(RootOperationChanged, Breaking, oldType.TypeName(), "Type %q is no longer the %s root type.", oldType.TypeName(), op)
+		case oldType.TypeName() != newType.TypeName():
+			c.add(RootOperationChanged, Breaking, newType.TypeName(), "The %s root type changed from %q to %q.", op, oldType.TypeName(), newType.TypeName())
+		}
+	}
+}
+
+func (c *comparison) compareTypes(oldSchema, newSchema *types.Schema) {
+	for _, name := range sortedTypeNames(oldSchema) {
+		oldType := oldSchema.Types[name]
+		newType, ok := newSchema.Types[name]
+		if !ok {
+			c.add(TypeRemoved, Breaking, name, "Type %q was removed.", name)
+			continue
+		}
+		if oldType.Kind() != newType.Kind() {
+			c.add(TypeKindChanged, Breaking, name, "Type %q changed from %s to %s.", name, oldType.Kind(), newType.Kind())
+			continue
+		}
+		if oldType.Description() != newType.Description() {
+			c.add(TypeDescriptionChanged, Safe, name, "Description of type %q changed.", name)
+		}
+
+		switch oldType := oldType.(type) {
+		case *types.ObjectTypeDefinition:
+			newType := newType.(*types.ObjectTypeDefinition)
+			c.compareFields(name, oldType.Fields, newType.Fields)
+			c.compareInterfaces(name, oldType.Interfaces, newType.Interfaces)
+		case *types.InterfaceTypeDefinition:
+			c.compareFields(name, oldType.Fields, newType.(*types.InterfaceTypeDefinition).Fields)
+		case *types.Union:
+			c.compareUnions(oldType, newType.(*types.Union))
+		case *types.EnumTypeDefinition:
+			c.compareEnums(oldType, newType.(*types.EnumTypeDefinition))
+		case *types.InputObject:
+			c.compareInputFields(oldType, newType.(*types.InputObject))
+		}
+	}
+
+	for _, name := range sortedTypeNames(newSchema) {
+		if _, ok := oldSchema.Types[name]; !ok {
+			c.add(TypeAdded, Safe, name, "Type %q was added.", name)
+		}
+	}
+}
+
+func (c *comparison) compareFields(typeName string, oldFields, newFields types.FieldsDefinition) {
+	for _, oldField := range oldFields {
+		path := typeName + "." + oldField.Name
+		newField := newFields.Get(oldField.Name)
+		if newField == nil {
+			criticality := Breaking
+			if oldField.Directives.Get("deprecated") != nil {
+				criticality = Dangerous
+			}
+			c.add(FieldRemoved, criticality, path, "Field %q was removed from %q.", oldField.Name, typeName)
+			continue
+		}
+
+		if !isSafeOutputChange(oldField.Type, newField.Type) {
+			c.add(FieldTypeChanged, Breaking, path, "Field %q changed type from %q to %q.", path, oldField.Type, newField.Type)
+		} else if oldField.Type.String() != newField.Type.String() {
+			c.add(FieldTypeChanged, Safe, path, "Field %q changed type from %q to %q.", path, oldField.Type, newField.Type)
+		}
+		if oldField.Desc != newField.Desc {
+			c.add(FieldDescriptionChanged, Safe, path, "Description of field %q changed.", path)
+		}
+		oldDeprecated := oldField.Directives.Get("deprecated") != nil
+		newDeprecated := newField.Directives.Get("deprecated") != nil
+		if !oldDeprecated && newDeprecated {
+			c.add(FieldDeprecationAdded, Safe, path, "Field %q was deprecated.", path)
+		}
+		if oldDeprecated && !newDeprecated {
+			c.add(FieldDeprecationRemoved, Safe, path, "Field %q is no longer deprecated.", path)
+		}
+
+		c.compareArgs(path, oldField.Arguments, newField.Arguments)
+	}
+
+	for _, newField := range newFields {
+		if oldFields.Get(newField.Name) == nil {
+			c.add(FieldAdded, Safe, typeName+"."+newField.Name, "Field %q was added to %q.", newField.Name, typeName)
+		}
+	}
+}
+
+func (c *comparison) compareArgs(fieldPath string, oldArgs, newArgs types.ArgumentsDefinition) {
+	for _, oldArg := range oldArgs {
+		path := fieldPath + "(" + oldArg.Name.Name + ":)"
+		newArg := newArgs.Get(oldArg.Name.Name)
+		if newArg == nil {
+			c.add(ArgRemoved, Breaking, path, "Argument %q was removed from %q.", oldArg.Name.Name, fieldPath)
+			continue
+		}
+		if !isSafeInputChange(oldArg.Type, newArg.Type) {
+			c.add(ArgTypeChanged, Breaking, path, "Argument %q changed type from %q to %q.", path, oldArg.Type, newArg.Type)
+		} else if oldArg.Type.String() != newArg.Type.String() {
+			c.add(ArgTypeChanged, Safe, path, "Argument %q changed type from %q to %q.", path, oldArg.Type, newArg.Type)
+		}
+		if valueString(oldArg.Default) != valueString(newArg.Default) {
+			c.add(ArgDefaultChanged, Dangerous, path, "Default value of argument %q changed from %s to %s.", path, valueString(oldArg.Default), valueString(newArg.Default))
+		}
+		if oldArg.Desc != newArg.Desc {
+			c.add(ArgDescriptionChanged, Safe, path, "Description of argument %q changed.", path)
+		}
+	}
+
+	for _, newArg := range newArgs {
+		if oldArgs.Get(newArg.Name.Name) != nil {
+			continue
+		}
+		path := fieldPath + "(" + newArg.Name.Name + ":)"
+		if isRequired(newArg) {
+			c.add(ArgAdded, Breaking, path, "Required argument %q was added to %q.", newArg.Name.Name, fieldPath)
+		} else {
+			c.add(ArgAdded, Dangerous, path, "Optional argument %q was added to %q.", newArg.Name.Name, fieldPath)
+		}
+	}
+}
+
+func (c *comparison) compareInterfaces(typeName string, oldInterfaces, newInterfaces []*types.InterfaceTypeDefinition) {
+	for _, oldIntf := range oldInterfaces {
+		if !containsInterface(newInterfaces, oldIntf.Name) {
+			c.add(InterfaceRemoved, Breaking, typeName, "Type %q no longer implements interface %q.", typeName, oldIntf.Name)
+		}
+	}
+	for _, newIntf := range newInterfaces {
+		if !containsInterface(oldInterfaces, newIntf.Name) {
+			c.add(InterfaceAdded, Dangerous, typeName, "Type %q now implements interface %q.", typeName, newIntf.Name)
+		}
+	}
+}
+
+func (c *comparison) compareUnions(oldUnion, newUnion *types.Union) {
+	for _, oldMember := range oldUnion.UnionMemberTypes {
+		if !containsObject(newUnion.UnionMemberTypes, oldMember.Name) {
+			c.add(UnionMemberRemoved, Breaking, oldUnion.Name, "Member %q was removed from union %q.", oldMember.Name, oldUnion.Name)
+		}
+	}
+	for _, newMember := range newUnion.UnionMemberTypes {
+		if !containsObject(oldUnion.UnionMemberTypes, newMember.Name) {
+			c.add(UnionMemberAdded, Dangerous, newUnion.Name, "Member %q was added to union %q.", newMember.Name, newUnion.Name)
+		}
+	}
+}
+
+func (c *comparison) compareEnums(oldEnum, newEnum *types.EnumTypeDefinition) {
+	for _, oldValue := range oldEnum.EnumValuesDefinition {
+		path := oldEnum.Name + "." + oldValue.EnumValue
+		newValue := enumValue(newEnum, oldValue.EnumValue)
+		if newValue == nil {
+			c.add(EnumValueRemoved, Breaking, path, "Value %q was removed from enum %q.", oldValue.EnumValue, oldEnum.Name)
+			continue
+		}
+		oldDeprecated := oldValue.Directives.Get("deprecated") != nil
+		newDeprecated := newValue.Directives.Get("deprecated") != nil
+		if !oldDeprecated && newDeprecated {
+			c.add(EnumValueDeprecated, Safe, path, "Enum value %q was deprecated.", path)
+		}
+		if oldDeprecated && !newDeprecated {
+			c.add(EnumValueUndeprecated, Safe, path, "Enum value %q is no longer deprecated.", path)
+		}
+	}
+	for _, newValue := range newEnum.EnumValuesDefinition {
+		if enumValue(oldEnum, newValue.EnumValue) == nil {
+			c.add(EnumValueAdded, Dangerous, newEnum.Name+"."+newValue.EnumValue, "Value %q was added to enum %q.", newValue.EnumValue, newEnum.Name)
+		}
+	}
+}
+
+func (c *comparison) compareInputFields(oldInput, newInput *types.InputObject) {
+	for _, oldField := range oldInput.Values {
+		path := oldInput.Name + "." + oldField.Name.Name
+		newField := newInput.Values.Get(oldField.Name.Name)
+		if newField == nil {
+			c.add(InputFieldRemoved, Breaking, path, "Input field %q was removed from %q.", oldField.Name.Name, oldInput.Name)
+			continue
+		}
+		if !isSafeInputChange(oldField.Type, newField.Type) {
+			c.add(InputFieldTypeChanged, Breaking, path, "Input field %q changed type from %q to %q.", path, oldField.Type, newField.Type)
+		} else if oldField.Type.String() != newField.Type.String() {
+			c.add(InputFieldTypeChanged, Safe, path, "Input field %q changed type from %q to %q.", path, oldField.Type, newField.Type)
+		}
+		if valueString(oldField.Default) != valueString(newField.Default) {
+			c.add(InputFieldDefaultChanged, Dangerous, path, "Default value of input field %q changed from %s to %s.", path, valueString(oldField.Default), valueString(newField.Default))
+		}
+	}
+	for _, newField := range newInput.Values {
+		if oldInput.Values.Get(newField.Name.Name) != nil {
+			continue
+		}
+		path := newInput.Name + "." + newField.Name.Name
+		if isRequired(newField) {
+			c.add(InputFieldAdded, Breaking, path, "Required input field %q was added to %q.", newField.Name.Name, newInput.Name)
+		} else {
+			c.add(InputFieldAdded, Dangerous, path, "Optional input field %q was added to %q.", newField.Name.Name, newInput.Name)
+		}
+	}
+}
+
+func (c *comparison) compareDirectives(oldSchema, newSchema *types.Schema) {
+	for _, name := range sortedDirectiveNames(oldSchema) {
+		oldDir := oldSchema.Directives[name]
+		path := "@" + name
+		newDir, ok := newSchema.Directives[name]
+		if !ok {
+			c.add(DirectiveRemoved, Breaking, path, "Directive %q was removed.", path)
+			continue
+		}
+
+		for _, loc := range oldDir.Locations {
+			if !containsString(newDir.Locations, loc) {
+				c.add(DirectiveLocationRemoved, Breaking, path, "Location %s was removed from directive %q.", loc, path)
+			}
+		}
+		for _, loc := range newDir.Locations {
+			if !containsString(oldDir.Locations, loc) {
+				c.add(DirectiveLocationAdded, Safe, path, "Location %s was added to directive %q.", loc, path)
+			}
+		}
+
+		for _, oldArg := range oldDir.Arguments {
+			argPath := path + "(" + oldArg.Name.Name + ":)"
+			newArg := newDir.Arguments.Get(oldArg.Name.Name)
+			if newArg == nil {
+				c.add(DirectiveArgRemoved, Breaking, argPath, "Argument %q was removed from directive %q.", oldArg.Name.Name, path)
+				continue
+			}
+			if !isSafeInputChange(oldArg.Type, newArg.Type) {
+				c.add(DirectiveArgTypeChanged, Breaking, argPath, "Argument %q changed type from %q to %q.", argPath, oldArg.Type, newArg.Type)
+			}
+		}
+		for _, newArg := range newDir.Arguments {
+			if oldDir.Arguments.Get(newArg.Name.Name) != nil {
+				continue
+			}
+			argPath := path + "(" + newArg.Name.Name + ":)"
+			if isRequired(newArg) {
+				c.add(DirectiveArgAdded, Breaking, argPath, "Required argument %q was added to directive %q.", newArg.Name.Name, path)
+			} else {
+				c.add(DirectiveArgAdded, Safe, argPath, "Optional argument %q was added to directive %q.", newArg.Name.Name, path)
+			}
+		}
+	}
+
+	for _, name := range sortedDirectiveNames(newSchema) {
+		if _, ok := oldSchema.Directives[name]; !ok {
+			c.add(DirectiveAdded, Safe, "@"+name, "Directive %q was added.", "@"+name)
+		}
+	}
+}
+
+// isSafeOutputChange reports whether values of type newType can be returned to clients that
+// expect oldType. Output types may become stricter, i.e. nullable types may become non-null.
+func isSafeOutputChange(oldType, newType types.Type) bool {
+	switch oldType := oldType.(type) {
+	case *types.List:
+		if newList, ok := newType.(*types.List); ok {
+			return isSafeOutputChange(oldType.OfType, newList.OfType)
+		}
+		if newNonNull, ok := newType.(*types.NonNull); ok {
+			return isSafeOutputChange(oldType, newNonNull.OfType)
+		}
+		return false
+	case *types.NonNull:
+		if newNonNull, ok := newType.(*types.NonNull); ok {
+			return isSafeOutputChange(oldType.OfType, newNonNull.OfType)
+		}
+		return false
+	case types.NamedType:
+		if newNonNull, ok := newType.(*types.NonNull); ok {
+			return isSafeOutputChange(oldType, newNonNull.OfType)
+		}
+		newNamed, ok := newType.(types.NamedType)
+		return ok && newNamed.TypeName() == oldType.TypeName()
+	default:
+		return false
+	}
+}
+
+// isSafeInputChange reports whether values that clients send for oldType are accepted for
+// newType. Input types may become more lenient, i.e. non-null types may become nullable.
+func isSafeInputChange(oldType, newType types.Type) bool {
+	switch oldType := oldType.(type) {
+	case *types.List:
+		newList, ok := newType.(*types.List)
+		return ok && isSafeInputChange(oldType.OfType, newList.OfType)
+	case *types.NonNull:
+		if newNonNull, ok := newType.(*types.NonNull); ok {
+			return isSafeInputChange(oldType.OfType, newNonNull.OfType)
+		}
+		return isSafeInputChange(oldType.OfType, newType)
+	case types.NamedType:
+		newNamed, ok := newType.(types.NamedType)
+		return ok && newNamed.TypeName() == oldType.TypeName()
+	default:
+		return false
+	}
+}
+
+func isRequired(v *types.InputValueDefinition) bool {
+	_, nonNull := v.Type.(*types.NonNull)
+	return nonNull && v.Default == nil
+}
+
+func valueString(v types.Value) string {
+	if v == nil {
+		return "none"
+	}
+	return v.String()
+}
+
+func enumValue(e *types.EnumTypeDefinition, name string) *types.EnumValueDefinition {
+	for _, v := range e.EnumValuesDefinition {
+		if v.EnumValue == name {
+			return v
+		}
+	}
+	return nil
+}
+
+func containsInterface(l []*types.InterfaceTypeDefinition, name string) bool {
+	for _, t := range l {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsObject(l []*types.ObjectTypeDefinition, name string) bool {
+	for _, t := range l {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedTypeNames(s *types.Schema) []string {
+	names := make([]string, 0, len(s.Types))
+	for name := range s.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedDirectiveNames(s *types.Schema) []string {
+	names := make([]string, 0, len(s.Directives))
+	for name := range s.Directives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
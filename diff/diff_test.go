@@ -0,0 +1,182 @@
+package diff_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go/diff"
+	"github.com/graph-gophers/graphql-go/internal/schema"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+func parse(t *testing.T, sdl string) *types.Schema {
+	t.Helper()
+	s, err := schema.ParseSchema(sdl, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestCompare(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		oldSchema string
+		newSchema string
+		want      []diff.Change
+	}{
+		{
+			name:      "identical schemas",
+			oldSchema: `type Query { hello(name: String = "world"): String! }`,
+			newSchema: `type Query { hello(name: String = "world"): String! }`,
+			want:      nil,
+		},
+		{
+			name:      "field added and removed",
+			oldSchema: `type Query { a: String b: String }`,
+			newSchema: `type Query { a: String c: String }`,
+			want: []diff.Change{
+				{Type: diff.FieldRemoved, Criticality: diff.Breaking, Path: "Query.b", Message: `Field "b" was removed from "Query".`},
+				{Type: diff.FieldAdded, Criticality: diff.Safe, Path: "Query.c", Message: `Field "c" was added to "Query".`},
+			},
+		},
+		{
+			name:      "field type changes",
+			oldSchema: `type Query { a: String b: String! c: [Int] }`,
+			newSchema: `type Query { a: String! b: String c: Int }`,
+			want: []diff.Change{
+				{Type: diff.FieldTypeChanged, Criticality: diff.Safe, Path: "Query.a", Message: `Field "Query.a" changed type from "String" to "String!".`},
+				{Type: diff.FieldTypeChanged, Criticality: diff.Breaking, Path: "Query.b", Message: `Field "Query.b" changed type from "String!" to "String".`},
+				{Type: diff.FieldTypeChanged, Criticality: diff.Breaking, Path: "Query.c", Message: `Field "Query.c" changed type from "[Int]" to "Int".`},
+			},
+		},
+		{
+			name:      "arguments",
+			oldSchema: `type Query { a(x: Int!, y: Int, z: Int = 1): String }`,
+			newSchema: `type Query { a(x: Int, z: Int = 2, req: ID!, opt: ID): String }`,
+			want: []diff.Change{
+				{Type: diff.ArgAdded, Criticality: diff.Dangerous, Path: "Query.a(opt:)", Message: `Optional argument "opt" was added to "Query.a".`},
+				{Type: diff.ArgAdded, Criticality: diff.Breaking, Path: "Query.a(req:)", Message: `Required argument "req" was added to "Query.a".`},
+				{Type: diff.ArgTypeChanged, Criticality: diff.Safe, Path: "Query.a(x:)", Message: `Argument "Query.a(x:)" changed type from "Int!" to "Int".`},
+				{Type: diff.ArgRemoved, Criticality: diff.Breaking, Path: "Query.a(y:)", Message: `Argument "y" was removed from "Query.a".`},
+				{Type: diff.ArgDefaultChanged, Criticality: diff.Dangerous, Path: "Query.a(z:)", Message: `Default value of argument "Query.a(z:)" changed from 1 to 2.`},
+			},
+		},
+		{
+			name: "enums, unions and interfaces",
+			oldSchema: `
+				type Query { r: Role u: U n: Node }
+				enum Role { ADMIN USER }
+				interface Node { id: ID! }
+				type A implements Node { id: ID! }
+				type B { id: ID! }
+				union U = A | B
+			`,
+			newSchema: `
+				type Query { r: Role u: U n: Node }
+				enum Role { USER GUEST }
+				interface Node { id: ID! }
+				type A { id: ID! }
+				type B implements Node { id: ID! }
+				union U = B
+			`,
+			want: []diff.Change{
+				{Type: diff.InterfaceRemoved, Criticality: diff.Breaking, Path: "A", Message: `Type "A" no longer implements interface "Node".`},
+				{Type: diff.InterfaceAdded, Criticality: diff.Dangerous, Path: "B", Message: `Type "B" now implements interface "Node".`},
+				{Type: diff.EnumValueRemoved, Criticality: diff.Breaking, Path: "Role.ADMIN", Message: `Value "ADMIN" was removed from enum "Role".`},
+				{Type: diff.EnumValueAdded, Criticality: diff.Dangerous, Path: "Role.GUEST", Message: `Value "GUEST" was added to enum "Role".`},
+				{Type: diff.UnionMemberRemoved, Criticality: diff.Breaking, Path: "U", Message: `Member "A" was removed from union "U".`},
+			},
+		},
+		{
+			name: "input objects",
+			oldSchema: `
+				type Query { f(in: In): String }
+				input In { a: Int! b: String c: Int = 1 }
+			`,
+			newSchema: `
+				type Query { f(in: In): String }
+				input In { a: Int c: Int = 3 d: ID! e: ID }
+			`,
+			want: []diff.Change{
+				{Type: diff.InputFieldTypeChanged, Criticality: diff.Safe, Path: "In.a", Message: `Input field "In.a" changed type from "Int!" to "Int".`},
+				{Type: diff.InputFieldRemoved, Criticality: diff.Breaking, Path: "In.b", Message: `Input field "b" was removed from "In".`},
+				{Type: diff.InputFieldDefaultChanged, Criticality: diff.Dangerous, Path: "In.c", Message: `Default value of input field "In.c" changed from 1 to 3.`},
+				{Type: diff.InputFieldAdded, Criticality: diff.Breaking, Path: "In.d", Message: `Required input field "d" was added to "In".`},
+				{Type: diff.InputFieldAdded, Criticality: diff.Dangerous, Path: "In.e", Message: `Optional input field "e" was added to "In".`},
+			},
+		},
+		{
+			name: "types and directives",
+			oldSchema: `
+				directive @auth(role: String!) on FIELD_DEFINITION | OBJECT
+				directive @old on FIELD
+				type Query { a: T }
+				type T { id: ID }
+			`,
+			newSchema: `
+				directive @auth(role: String!, scope: String) on FIELD_DEFINITION
+				type Query { a: T }
+				interface T { id: ID }
+				scalar New
+			`,
+			want: []diff.Change{
+				{Type: diff.DirectiveLocationRemoved, Criticality: diff.Breaking, Path: "@auth", Message: `Location OBJECT was removed from directive "@auth".`},
+				{Type: diff.DirectiveArgAdded, Criticality: diff.Safe, Path: "@auth(scope:)", Message: `Optional argument "scope" was added to directive "@auth".`},
+				{Type: diff.DirectiveRemoved, Criticality: diff.Breaking, Path: "@old", Message: `Directive "@old" was removed.`},
+				{Type: diff.TypeAdded, Criticality: diff.Safe, Path: "New", Message: `Type "New" was added.`},
+				{Type: diff.TypeKindChanged, Criticality: diff.Breaking, Path: "T", Message: `Type "T" changed from OBJECT to INTERFACE.`},
+			},
+		},
+		{
+			name:      "deprecations",
+			oldSchema: `type Query { a: String b: String @deprecated }`,
+			newSchema: `type Query { a: String @deprecated }`,
+			want: []diff.Change{
+				{Type: diff.FieldDeprecationAdded, Criticality: diff.Safe, Path: "Query.a", Message: `Field "Query.a" was deprecated.`},
+				{Type: diff.FieldRemoved, Criticality: diff.Dangerous, Path: "Query.b", Message: `Field "b" was removed from "Query".`},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diff.Compare(parse(t, tt.oldSchema), parse(t, tt.newSchema))
+			if len(got) != len(tt.want) {
+				t.Fatalf("want %d changes, got %d: %v", len(tt.want), len(got), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("change %d:\nwant %v\ngot  %v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHasBreaking(t *testing.T) {
+	changes := diff.Compare(
+		parse(t, `type Query { a: String }`),
+		parse(t, `type Query { a: String b: Int }`),
+	)
+	if diff.HasBreaking(changes) {
+		t.Errorf("adding a field must not be breaking: %v", changes)
+	}
+
+	changes = diff.Compare(
+		parse(t, `type Query { a: String }`),
+		parse(t, `type Query { b: Int }`),
+	)
+	if !diff.HasBreaking(changes) {
+		t.Errorf("removing a field must be breaking: %v", changes)
+	}
+}
+
+func TestChangeJSON(t *testing.T) {
+	b, err := json.Marshal(diff.Change{Type: diff.FieldRemoved, Criticality: diff.Breaking, Path: "Query.a", Message: "removed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"FIELD_REMOVED","criticality":"BREAKING","path":"Query.a","message":"removed"}`
+	if string(b) != want {
+		t.Errorf("want %s, got %s", want, b)
+	}
+}
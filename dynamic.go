@@ -0,0 +1,18 @@
+package graphql
+
+import "context"
+
+// DynamicObject resolves the fields of an object at execution time instead of binding methods
+// or struct fields to the schema.
+//
+// Resolvers of object, interface and union types may be a DynamicObject or a map with string
+// keys. The fields of a map are resolved from its entries, and the values of a dynamic
+// object's fields are resolved dynamically in turn: objects again from maps or DynamicObjects,
+// lists from slices and scalars and enums are serialized as they are. The object type of an
+// interface or union is given by the GraphQLTypeName() string method of a DynamicObject or the
+// "__typename" entry of a map.
+type DynamicObject interface {
+	// Resolve returns the value of the field with the given name. Args holds the arguments of the
+	// field, including default values.
+	Resolve(ctx context.Context, field string, args map[string]interface{}) (interface{}, error)
+}
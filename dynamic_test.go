@@ -0,0 +1,256 @@
+package graphql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+)
+
+type dynamicDocument map[string]interface{}
+
+func (d dynamicDocument) GraphQLTypeName() string { return "Document" }
+
+func (d dynamicDocument) Resolve(ctx context.Context, field string, args map[string]interface{}) (interface{}, error) {
+	switch field {
+	case "title":
+		if args["upper"] == true {
+			return fmt.Sprintf("%s!", d["title"]), nil
+		}
+		return d["title"], nil
+	case "missing":
+		return nil, fmt.Errorf("document has no %s", field)
+	}
+	return d[field], nil
+}
+
+type dynamicQuery struct{}
+
+func (*dynamicQuery) Settings() map[string]interface{} {
+	return map[string]interface{}{"theme": "dark", "limits": []interface{}{1, 2}, "owners": map[string]interface{}{"id": 1}}
+}
+
+func TestDynamicResolvers(t *testing.T) {
+	schema := graphql.MustParseSchema(`
+		type Query {
+			user(id: ID!): User
+			search: [SearchResult!]!
+		}
+		type User {
+			id: ID!
+			name: String!
+			age: Int
+			role: Role!
+			friends: [User!]!
+		}
+		type Document {
+			title(upper: Boolean = false): String!
+			missing: String
+		}
+		enum Role { ADMIN, MEMBER }
+		union SearchResult = User | Document
+	`, map[string]interface{}{
+		"user": map[string]interface{}{
+			"id":   "1",
+			"name": "Ada",
+			"role": "ADMIN",
+			"friends": []interface{}{
+				map[string]interface{}{"id": "2", "name": "Charles", "age": 42, "role": "MEMBER", "friends": []interface{}{}},
+			},
+		},
+		"search": []interface{}{
+			map[string]interface{}{"__typename": "User", "id": "1", "name": "Ada"},
+			dynamicDocument{"title": "Notes"},
+		},
+	})
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: schema,
+			Query: `
+				{
+					user(id: "1") {
+						name
+						age
+						role
+						friends { id name age role }
+					}
+					search {
+						__typename
+						... on User { name }
+						... on Document { title loud: title(upper: true) }
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"user": {
+						"name": "Ada",
+						"age": null,
+						"role": "ADMIN",
+						"friends": [{"id": "2", "name": "Charles", "age": 42, "role": "MEMBER"}]
+					},
+					"search": [
+						{"__typename": "User", "name": "Ada"},
+						{"__typename": "Document", "title": "Notes", "loud": "Notes!"}
+					]
+				}
+			`,
+		},
+		{
+			Schema: schema,
+			Query: `
+				{
+					search {
+						... on Document { missing }
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"search": [{}, {"missing": null}]
+				}
+			`,
+			ExpectedErrors: []*errors.QueryError{{
+				Message:       "document has no missing",
+				Locations:     []errors.Location{{Line: 4, Column: 25}},
+				Path:          []interface{}{"search", 1, "missing"},
+				ResolverError: fmt.Errorf("document has no missing"),
+			}},
+		},
+	})
+
+	gqltesting.RunTest(t, &gqltesting.Test{
+		Schema: graphql.MustParseSchema(`
+			type Query { settings: Settings! }
+			type Settings { theme: String! limits: [Int!]! }
+		`, &dynamicQuery{}),
+		Query: `
+			{
+				settings { theme limits }
+			}
+		`,
+		ExpectedResult: `
+			{
+				"settings": {"theme": "dark", "limits": [1, 2]}
+			}
+		`,
+	})
+
+	gqltesting.RunTest(t, &gqltesting.Test{
+		Schema: graphql.MustParseSchema(`
+			type Query { settings: Settings! }
+			type Settings { theme: [String!] limits: [Int!] owners: [Int] }
+		`, &dynamicQuery{}),
+		Query: `
+			{
+				settings { theme limits owners }
+			}
+		`,
+		ExpectedResult: `
+			{
+				"settings": {"theme": null, "limits": [1, 2], "owners": null}
+			}
+		`,
+		ExpectedErrors: []*errors.QueryError{
+			{
+				Message:   "could not resolve string as [String!]: expected a slice or an array",
+				Locations: []errors.Location{{Line: 3, Column: 16}},
+				Path:      []interface{}{"settings", "theme"},
+			},
+			{
+				Message:   "could not resolve map[string]interface {} as [Int]: expected a slice or an array",
+				Locations: []errors.Location{{Line: 3, Column: 29}},
+				Path:      []interface{}{"settings", "owners"},
+			},
+		},
+	})
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: dynamicConstraintSchema,
+			Query: `
+				{
+					search(term: "go", filter: {tags: ["#a"]})
+				}
+			`,
+			ExpectedResult: `
+				{
+					"search": "found"
+				}
+			`,
+		},
+		{
+			Schema: dynamicConstraintSchema,
+			Query: `
+				{
+					search(term: "g")
+				}
+			`,
+			ExpectedResult: `{}`,
+			ExpectedErrors: []*errors.QueryError{{
+				Message:   `invalid value for "term": must have at least 2 characters`,
+				Locations: []errors.Location{{Line: 3, Column: 19}},
+				Extensions: map[string]interface{}{
+					"code":     "CONSTRAINT_VIOLATION",
+					"argument": "term",
+				},
+			}},
+		},
+		{
+			Schema: dynamicConstraintSchema,
+			Query: `
+				query($filter: Filter) {
+					search(term: "go", filter: $filter)
+				}
+			`,
+			Variables: map[string]interface{}{
+				"filter": map[string]interface{}{"tags": []interface{}{"#a", "b"}},
+			},
+			ExpectedResult: `{}`,
+			ExpectedErrors: []*errors.QueryError{{
+				Message:   `invalid value for "filter.tags[1]": must match the pattern "^#"`,
+				Locations: []errors.Location{{Line: 3, Column: 33}},
+				Extensions: map[string]interface{}{
+					"code":     "CONSTRAINT_VIOLATION",
+					"argument": "filter.tags[1]",
+				},
+			}},
+		},
+	})
+}
+
+type mapLeafQuery struct{}
+
+func (*mapLeafQuery) Count() map[string]interface{} { return nil }
+
+func (*mapLeafQuery) Settings() map[string]interface{} { return nil }
+
+// Maps only resolve object types dynamically, so binding one to a leaf or list type fails.
+func TestDynamicResolvers_leafTypes(t *testing.T) {
+	_, err := graphql.ParseSchema(`
+		type Query {
+			count: Int!
+			settings: [Settings]!
+		}
+		type Settings { theme: String! }
+	`, &mapLeafQuery{})
+	want := "2 resolver binding errors:\n" +
+		"Query.count (*graphql_test.mapLeafQuery): can not use map[string]interface {} as Int\n\t\tused by (*graphql_test.mapLeafQuery).Count\n" +
+		"Query.settings (*graphql_test.mapLeafQuery): map[string]interface {} is not a slice\n\t\tused by (*graphql_test.mapLeafQuery).Settings"
+	if err == nil || err.Error() != want {
+		t.Fatalf("want error %q, got %q", want, err)
+	}
+}
+
+var dynamicConstraintSchema = graphql.MustParseSchema(graphql.ConstraintDirective+`
+	input Filter {
+		tags: [String!] @constraint(pattern: "^#")
+	}
+	type Query {
+		search(term: String! @constraint(minLength: 2), filter: Filter): String!
+	}
+`, map[string]interface{}{"search": "found"})
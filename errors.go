@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/log"
+)
+
+// ErrorPresenterFunc returns the error to report to the client in place of err.
+type ErrorPresenterFunc func(ctx context.Context, err *errors.QueryError) *errors.QueryError
+
+// presentErrors applies the error presenter of the schema, if any, to errs.
+func (s *Schema) presentErrors(ctx context.Context, errs []*errors.QueryError) []*errors.QueryError {
+	if s.errorPresenter == nil {
+		return errs
+	}
+	for i, err := range errs {
+		errs[i] = s.errorPresenter(ctx, err)
+	}
+	return errs
+}
+
+// presentResponses applies the error presenter of the schema, if any, to the errors of the
+// responses of a subscription. The returned channel is closed when ctx is done, even if the
+// subscriber stops reading.
+func (s *Schema) presentResponses(ctx context.Context, responses <-chan interface{}) <-chan interface{} {
+	if s.errorPresenter == nil {
+		return responses
+	}
+	c := make(chan interface{})
+	go func() {
+		defer close(c)
+		for resp := range responses {
+			if resp, ok := resp.(*Response); ok {
+				resp.Errors = s.presentErrors(ctx, resp.Errors)
+			}
+			select {
+			case c <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
+
+func (s *Schema) internalErrorFunc() func(ctx context.Context, err *errors.QueryError, resolverErr error) *errors.QueryError {
+	if !s.maskInternalErrors {
+		return nil
+	}
+	return s.maskInternalError
+}
+
+// maskInternalError replaces an error returned by a resolver or made from a panic with a generic
+// one carrying a correlation ID, unless the error is allowed, and logs the original error.
+func (s *Schema) maskInternalError(ctx context.Context, err *errors.QueryError, resolverErr error) *errors.QueryError {
+	if resolverErr != nil && s.allowError != nil && s.allowError(resolverErr) {
+		return err
+	}
+
+	id := newCorrelationID()
+	logged := resolverErr
+	if logged == nil {
+		logged = err
+	}
+	l, ok := s.logger.(log.ErrorLogger)
+	if !ok {
+		// loggers which only log panics fall back to the standard logger
+		l = &log.DefaultLogger{}
+	}
+	l.LogError(ctx, id, logged)
+	return &errors.QueryError{
+		Message:       "internal server error",
+		Locations:     err.Locations,
+		Path:          err.Path,
+		ResolverError: err.ResolverError,
+		Extensions:    map[string]interface{}{"correlationId": id},
+	}
+}
+
+func newCorrelationID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
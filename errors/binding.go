@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BindingError describes a resolver that does not match the schema it is bound to.
+type BindingError struct {
+	// Coordinate is the schema coordinate of the element that can not be resolved, e.g.
+	// "Type.field", "Type.field(arg:)" or the name of an abstract type.
+	Coordinate string
+
+	// GoType is the Go type on which the problem was found: the resolver of the field or abstract
+	// type, or the struct receiving the arguments of the field.
+	GoType reflect.Type
+
+	// Err describes the problem.
+	Err error
+
+	// UsedBy describes how the resolver was reached from the root resolver, innermost first.
+	UsedBy []string
+}
+
+func (err *BindingError) Error() string {
+	str := err.Err.Error()
+	for _, u := range err.UsedBy {
+		str += "\n\tused by " + u
+	}
+	return str
+}
+
+func (err *BindingError) Unwrap() error {
+	return err.Err
+}
+
+// BindingErrors lists all problems found while binding resolvers to a schema.
+type BindingErrors []*BindingError
+
+func (errs BindingErrors) Error() string {
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d resolver binding errors:", len(errs))
+	for _, err := range errs {
+		fmt.Fprintf(&b, "\n%s (%s): %s", err.Coordinate, err.GoType, strings.Replace(err.Error(), "\n", "\n\t", -1))
+	}
+	return b.String()
+}
+
+var _ error = BindingErrors{}
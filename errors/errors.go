@@ -16,6 +16,10 @@ type QueryError struct {
 type Location struct {
 	Line   int `json:"line"`
 	Column int `json:"column"`
+
+	// Source is the name of the source document the location refers to. It is only set for
+	// schemas parsed from multiple named sources (see graphql.ParseSchemaSources).
+	Source string `json:"-"`
 }
 
 func (a Location) Before(b Location) bool {
@@ -34,9 +38,22 @@ func (err *QueryError) Error() string {
 	}
 	str := fmt.Sprintf("graphql: %s", err.Message)
 	for _, loc := range err.Locations {
+		if loc.Source != "" {
+			str += fmt.Sprintf(" (%s: line %d, column %d)", loc.Source, loc.Line, loc.Column)
+			continue
+		}
 		str += fmt.Sprintf(" (line %d, column %d)", loc.Line, loc.Column)
 	}
 	return str
 }
 
+// Unwrap returns the error returned by the resolver, so that errors.Is and errors.As see through
+// the QueryError.
+func (err *QueryError) Unwrap() error {
+	if err == nil {
+		return nil
+	}
+	return err.ResolverError
+}
+
 var _ error = &QueryError{}
@@ -0,0 +1,22 @@
+package errors
+
+import (
+	"strings"
+)
+
+// MultiError is returned by a resolver to report several errors for the same field. Each error
+// becomes a separate QueryError with the path of the field.
+type MultiError []error
+
+func (errs MultiError) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors, which errors.Is and errors.As inspect as of Go 1.20.
+func (errs MultiError) Unwrap() []error {
+	return errs
+}
@@ -0,0 +1,317 @@
+package graphql_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	stdlog "log"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+)
+
+type publicError struct{ msg string }
+
+func (e publicError) Error() string { return e.msg }
+
+type errorQuery struct{}
+
+func (*errorQuery) Internal() (*string, error) {
+	return nil, errors.New(`pq: relation "users" does not exist`)
+}
+
+func (*errorQuery) Public() (*string, error) {
+	return nil, publicError{"user not found"}
+}
+
+func (*errorQuery) Panic() *string {
+	panic("nil map")
+}
+
+const errorSchema = `
+	type Query {
+		internal: String
+		public: String
+		panic: String
+	}
+`
+
+type recordingLogger struct {
+	mu     sync.Mutex
+	errors map[string]string
+}
+
+func (l *recordingLogger) LogPanic(ctx context.Context, value interface{}) {}
+
+func (l *recordingLogger) LogError(ctx context.Context, correlationID string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors[correlationID] = err.Error()
+}
+
+func TestErrorPresenter(t *testing.T) {
+	presenter := func(ctx context.Context, err *gqlerrors.QueryError) *gqlerrors.QueryError {
+		err.Message = strings.ToUpper(err.Message)
+		err.Extensions = map[string]interface{}{"code": "NOT_FOUND"}
+		return err
+	}
+	gqltesting.RunTest(t, &gqltesting.Test{
+		Schema: graphql.MustParseSchema(errorSchema, &errorQuery{}, graphql.ErrorPresenter(presenter)),
+		Query: `
+			{
+				public
+			}
+		`,
+		ExpectedResult: `
+			{
+				"public": null
+			}
+		`,
+		ExpectedErrors: []*gqlerrors.QueryError{{
+			Message:       "USER NOT FOUND",
+			Locations:     []gqlerrors.Location{{Line: 3, Column: 5}},
+			Path:          []interface{}{"public"},
+			ResolverError: publicError{"user not found"},
+			Extensions:    map[string]interface{}{"code": "NOT_FOUND"},
+		}},
+	})
+}
+
+type tickSubscription struct{}
+
+func (*tickSubscription) Hello() string { return "hello" }
+
+func (*tickSubscription) Tick(ctx context.Context) <-chan int32 {
+	c := make(chan int32)
+	go func() {
+		defer close(c)
+		for i := int32(0); ; i++ {
+			select {
+			case c <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
+
+func TestErrorPresenter_subscriptionCancelled(t *testing.T) {
+	presenter := func(ctx context.Context, err *gqlerrors.QueryError) *gqlerrors.QueryError {
+		return err
+	}
+	schema := graphql.MustParseSchema(`
+		type Query { hello: String! }
+		type Subscription { tick: Int! }
+	`, &tickSubscription{}, graphql.ErrorPresenter(presenter))
+
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	c, err := schema.Subscribe(ctx, `subscription { tick }`, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-c
+	// the subscriber stops reading while the next events are relayed, then cancels
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("subscription goroutines still running: %d, want at most %d", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMaskInternalErrors(t *testing.T) {
+	logger := &recordingLogger{errors: make(map[string]string)}
+	allowed := func(err error) bool {
+		_, ok := err.(publicError)
+		return ok
+	}
+	schema := graphql.MustParseSchema(errorSchema, &errorQuery{},
+		graphql.Logger(logger),
+		graphql.MaskInternalErrors(allowed),
+	)
+
+	resp := schema.Exec(context.Background(), `{ internal public panic }`, "", nil)
+	if len(resp.Errors) != 3 {
+		t.Fatalf("want 3 errors, got %v", resp.Errors)
+	}
+	want := map[string]string{
+		"internal": `pq: relation "users" does not exist`,
+		"panic":    "graphql: panic occurred: nil map",
+	}
+	for _, err := range resp.Errors {
+		field := err.Path[0].(string)
+		if field == "public" {
+			if err.Message != "user not found" {
+				t.Errorf("allowed error was masked: %q", err.Message)
+			}
+			continue
+		}
+		if err.Message != "internal server error" {
+			t.Errorf("%s: error was not masked: %q", field, err.Message)
+		}
+		id, _ := err.Extensions["correlationId"].(string)
+		if id == "" {
+			t.Errorf("%s: missing correlation ID", field)
+		}
+		if logged := logger.errors[id]; logged != want[field] {
+			t.Errorf("%s: want logged error %q, got %q", field, want[field], logged)
+		}
+	}
+}
+
+var errNoRows = errors.New("no rows in result set")
+
+type codeError struct {
+	error
+	code string
+}
+
+func (e codeError) Unwrap() error { return e.error }
+
+func (e codeError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+type chainQuery struct{}
+
+func (*chainQuery) Wrapped() (*string, error) {
+	return nil, fmt.Errorf("loading user: %w", codeError{errNoRows, "NOT_FOUND"})
+}
+
+func validationErrors() error {
+	return gqlerrors.MultiError{
+		codeError{errors.New("name is too long"), "INVALID"},
+		errors.New("email is taken"),
+	}
+}
+
+func (*chainQuery) Multi() (*string, error) {
+	return nil, validationErrors()
+}
+
+func (*chainQuery) WrappedMulti() (*string, error) {
+	return nil, fmt.Errorf("update user: %w", validationErrors())
+}
+
+func TestErrorChain(t *testing.T) {
+	schema := graphql.MustParseSchema(`
+		type Query {
+			wrapped: String
+			multi: String
+			wrappedMulti: String
+		}
+	`, &chainQuery{})
+
+	resp := schema.Exec(context.Background(), `{ wrapped }`, "", nil)
+	if len(resp.Errors) != 1 {
+		t.Fatalf("want 1 error, got %v", resp.Errors)
+	}
+	if !errors.Is(resp.Errors[0], errNoRows) {
+		t.Errorf("errors.Is does not find the wrapped error in %v", resp.Errors[0])
+	}
+	var ce codeError
+	if !errors.As(resp.Errors[0], &ce) || ce.code != "NOT_FOUND" {
+		t.Errorf("errors.As does not find the wrapped error in %v", resp.Errors[0])
+	}
+	if code := resp.Errors[0].Extensions["code"]; code != "NOT_FOUND" {
+		t.Errorf("want extensions of the wrapped error, got %v", resp.Errors[0].Extensions)
+	}
+
+	gqltesting.RunTest(t, &gqltesting.Test{
+		Schema: schema,
+		Query: `
+			{
+				multi
+			}
+		`,
+		ExpectedResult: `
+			{
+				"multi": null
+			}
+		`,
+		ExpectedErrors: []*gqlerrors.QueryError{
+			{
+				Message:       "name is too long",
+				Locations:     []gqlerrors.Location{{Line: 3, Column: 5}},
+				Path:          []interface{}{"multi"},
+				ResolverError: codeError{errors.New("name is too long"), "INVALID"},
+				Extensions:    map[string]interface{}{"code": "INVALID"},
+			},
+			{
+				Message:       "email is taken",
+				Locations:     []gqlerrors.Location{{Line: 3, Column: 5}},
+				Path:          []interface{}{"multi"},
+				ResolverError: errors.New("email is taken"),
+			},
+		},
+	})
+
+	// a wrapped MultiError is split as well
+	gqltesting.RunTest(t, &gqltesting.Test{
+		Schema: schema,
+		Query: `
+			{
+				wrappedMulti
+			}
+		`,
+		ExpectedResult: `
+			{
+				"wrappedMulti": null
+			}
+		`,
+		ExpectedErrors: []*gqlerrors.QueryError{
+			{
+				Message:       "name is too long",
+				Locations:     []gqlerrors.Location{{Line: 3, Column: 5}},
+				Path:          []interface{}{"wrappedMulti"},
+				ResolverError: codeError{errors.New("name is too long"), "INVALID"},
+				Extensions:    map[string]interface{}{"code": "INVALID"},
+			},
+			{
+				Message:       "email is taken",
+				Locations:     []gqlerrors.Location{{Line: 3, Column: 5}},
+				Path:          []interface{}{"wrappedMulti"},
+				ResolverError: errors.New("email is taken"),
+			},
+		},
+	})
+}
+
+type panicLogger struct{}
+
+func (panicLogger) LogPanic(ctx context.Context, value interface{}) {}
+
+func TestMaskInternalErrors_panicLogger(t *testing.T) {
+	var buf bytes.Buffer
+	stdlog.SetOutput(&buf)
+	defer stdlog.SetOutput(os.Stderr)
+
+	// a logger without LogError falls back to the standard logger
+	schema := graphql.MustParseSchema(errorSchema, &errorQuery{},
+		graphql.Logger(panicLogger{}),
+		graphql.MaskInternalErrors(nil),
+	)
+	resp := schema.Exec(context.Background(), `{ internal }`, "", nil)
+	if len(resp.Errors) != 1 {
+		t.Fatalf("want 1 error, got %v", resp.Errors)
+	}
+	id, _ := resp.Errors[0].Extensions["correlationId"].(string)
+	want := fmt.Sprintf(`graphql: internal error %s: pq: relation "users" does not exist`, id)
+	if id == "" || !strings.Contains(buf.String(), want) {
+		t.Errorf("want log containing %q, got %q", want, buf.String())
+	}
+}
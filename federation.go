@@ -0,0 +1,288 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/internal/exec"
+	"github.com/graph-gophers/graphql-go/internal/schema"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// Federation makes the schema an Apollo Federation subgraph. The federation directives (@key,
+// @external, @requires, @provides, @shareable, @extends, @inaccessible, @override and @tag) and the
+// _Any and _FieldSet scalars are declared unless the schema defines them itself, and the fields
+//
+//	_service: _Service!
+//	_entities(representations: [_Any!]!): [_Entity]!
+//
+// are added to the query type. _Entity is the union of all object types with a resolvable @key
+// directive; each of them needs a reference resolver registered with EntityResolver. The schema
+// must be parsed from SDL with ParseSchema or ParseSchemaSources.
+func Federation() SchemaOpt {
+	return func(s *Schema) {
+		if s.federation == nil {
+			s.federation = &federation{entityResolvers: make(map[string]reflect.Value)}
+		}
+	}
+}
+
+// EntityResolver registers the reference resolver of the entity type typeName and implies
+// Federation. The gateway sends representations of entities, i.e. their __typename and key
+// fields, to the _entities field which passes each of them to the reference resolver of its type.
+// The reference resolver has the signature
+//
+//	func(ctx context.Context, representation R) (T, error)
+//
+// where R is map[string]interface{} or a type the representation is decoded into with
+// encoding/json, and T is the resolver type of typeName. If it returns a nil T, the entity
+// resolves to null. If it returns an error, the entity resolves to null and the error is reported
+// at the index of the representation, without affecting the other entities.
+func EntityResolver(typeName string, fn interface{}) SchemaOpt {
+	return func(s *Schema) {
+		Federation()(s)
+		s.federation.entityResolvers[typeName] = reflect.ValueOf(fn)
+	}
+}
+
+type federation struct {
+	sdl             string
+	entityResolvers map[string]reflect.Value
+}
+
+// federationDefinitions are the definitions a subgraph may use without declaring them.
+var federationDefinitions = []struct {
+	name string
+	sdl  string
+}{
+	{"_Any", `scalar _Any`},
+	{"_FieldSet", `scalar _FieldSet`},
+	{"_Service", `type _Service { sdl: String! }`},
+	{"@external", `directive @external on FIELD_DEFINITION | OBJECT`},
+	{"@requires", `directive @requires(fields: _FieldSet!) on FIELD_DEFINITION`},
+	{"@provides", `directive @provides(fields: _FieldSet!) on FIELD_DEFINITION`},
+	{"@key", `directive @key(fields: _FieldSet!, resolvable: Boolean = true) on OBJECT | INTERFACE`},
+	{"@extends", `directive @extends on OBJECT | INTERFACE`},
+	{"@shareable", `directive @shareable on OBJECT | FIELD_DEFINITION`},
+	{"@inaccessible", `directive @inaccessible on FIELD_DEFINITION | OBJECT | INTERFACE | UNION | ARGUMENT_DEFINITION | SCALAR | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION`},
+	{"@override", `directive @override(from: String!) on FIELD_DEFINITION`},
+	{"@tag", `directive @tag(name: String!) on FIELD_DEFINITION | OBJECT | INTERFACE | UNION | ARGUMENT_DEFINITION | SCALAR | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION`},
+}
+
+// extendSchema adds the federation definitions and the _service and _entities fields to the
+// declared but not yet resolved schema s, and binds the fields to their resolvers.
+func (f *federation) extendSchema(s *Schema, sources []schema.Source) error {
+	bodies := make([]string, len(sources))
+	for i, src := range sources {
+		bodies[i] = src.Body
+	}
+	f.sdl = strings.Join(bodies, "\n")
+
+	ts := s.schema
+	promoteExtendedEntities(ts)
+
+	var sdl strings.Builder
+	for _, def := range federationDefinitions {
+		if strings.HasPrefix(def.name, "@") {
+			if _, ok := ts.Directives[def.name[1:]]; ok {
+				continue
+			}
+		} else if _, ok := ts.Types[def.name]; ok {
+			continue
+		}
+		sdl.WriteString(def.sdl + "\n")
+	}
+
+	var entities []string
+	for _, obj := range ts.Objects {
+		if isEntity(obj) {
+			entities = append(entities, obj.Name)
+		}
+	}
+	for _, name := range entities {
+		if _, ok := f.entityResolvers[name]; !ok {
+			return fmt.Errorf("entity type %q has no reference resolver", name)
+		}
+	}
+	for _, name := range sortedKeys(f.entityResolvers) {
+		if !containsString(entities, name) {
+			return fmt.Errorf("can not register reference resolver for %q: not an object type with a resolvable @key directive", name)
+		}
+	}
+
+	queryName, ok := ts.EntryPointNames["query"]
+	if !ok {
+		queryName = "Query"
+	}
+	if _, ok := ts.Types[queryName]; !ok {
+		query := &types.ObjectTypeDefinition{Name: queryName}
+		ts.Types[queryName] = query
+		ts.Objects = append(ts.Objects, query)
+	}
+	sdl.WriteString("extend type " + queryName + " {\n\t_service: _Service!\n")
+	if len(entities) > 0 {
+		sdl.WriteString("\t_entities(representations: [_Any!]!): [_Entity]!\n")
+	}
+	sdl.WriteString("}\n")
+	if len(entities) > 0 {
+		sdl.WriteString("union _Entity = " + strings.Join(entities, " | ") + "\n")
+	}
+
+	if err := schema.Declare(ts, []schema.Source{{Name: "federation", Body: sdl.String()}}, false); err != nil {
+		return err
+	}
+
+	s.funcs[queryName+"._service"] = func() *federationService {
+		return &federationService{sdl: f.sdl}
+	}
+	if len(entities) > 0 {
+		s.funcs[queryName+"._entities"] = f.resolveEntities
+	}
+	return nil
+}
+
+// promoteExtendedEntities turns extensions of object types which are not defined in the schema
+// into definitions, as Federation 1 subgraphs extend entity types owned by other subgraphs.
+func promoteExtendedEntities(s *types.Schema) {
+	exts := s.Extensions[:0]
+	for _, ext := range s.Extensions {
+		obj, ok := ext.Type.(*types.ObjectTypeDefinition)
+		if ok && s.Types[obj.Name] == nil && obj.Directives.Get("key") != nil {
+			s.Types[obj.Name] = obj
+			s.Objects = append(s.Objects, obj)
+			continue
+		}
+		exts = append(exts, ext)
+	}
+	s.Extensions = exts
+}
+
+// isEntity reports whether obj has a @key directive without "resolvable: false".
+func isEntity(obj *types.ObjectTypeDefinition) bool {
+	for _, d := range obj.Directives {
+		if d.Name.Name != "key" {
+			continue
+		}
+		if v, ok := d.Arguments.Get("resolvable"); !ok || v.String() != "false" {
+			return true
+		}
+	}
+	return false
+}
+
+// concreteTypes maps the entity types to the Go types returned by their reference resolvers.
+func (f *federation) concreteTypes() (map[string]reflect.Type, error) {
+	m := make(map[string]reflect.Type, len(f.entityResolvers))
+	for _, name := range sortedKeys(f.entityResolvers) {
+		fn := f.entityResolvers[name]
+		if !fn.IsValid() || fn.Kind() != reflect.Func {
+			return nil, fmt.Errorf("reference resolver of %q must be a function", name)
+		}
+		t := fn.Type()
+		if t.NumIn() != 2 || t.In(0) != contextType || t.NumOut() != 2 || t.Out(1) != errorType {
+			return nil, fmt.Errorf("reference resolver of %q must have the signature func(context.Context, R) (T, error), got %s", name, t)
+		}
+		m[name] = t.Out(0)
+	}
+	return m, nil
+}
+
+// resolveEntities resolves each representation on its own. A representation which fails to
+// resolve becomes null and its error is reported at its index in the list.
+func (f *federation) resolveEntities(ctx context.Context, args struct{ Representations []entityRepresentation }) ([]interface{}, error) {
+	info := exec.FieldInfoFromContext(ctx)
+	entities := make([]interface{}, len(args.Representations))
+	for i, rep := range args.Representations {
+		entity, err := f.resolveEntity(ctx, rep)
+		if err != nil {
+			if info == nil {
+				return nil, fmt.Errorf("representation %d: %w", i, err)
+			}
+			info.AddItemError(ctx, i, err)
+			continue
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
+
+func (f *federation) resolveEntity(ctx context.Context, rep entityRepresentation) (interface{}, error) {
+	typeName, _ := rep["__typename"].(string)
+	fn, ok := f.entityResolvers[typeName]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity type %q", typeName)
+	}
+
+	in := reflect.New(fn.Type().In(1))
+	if m, ok := in.Interface().(*map[string]interface{}); ok {
+		*m = rep
+	} else {
+		b, err := json.Marshal(rep)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, in.Interface()); err != nil {
+			return nil, err
+		}
+	}
+
+	out := fn.Call([]reflect.Value{reflect.ValueOf(ctx), in.Elem()})
+	if err, _ := out[1].Interface().(error); err != nil {
+		return nil, err
+	}
+	if entity := out[0]; !((entity.Kind() == reflect.Ptr || entity.Kind() == reflect.Interface) && entity.IsNil()) {
+		return entity.Interface(), nil
+	}
+	return nil, nil
+}
+
+type federationService struct {
+	sdl string
+}
+
+func (s *federationService) SDL() string {
+	return s.sdl
+}
+
+// entityRepresentation is a value of the _Any scalar.
+type entityRepresentation map[string]interface{}
+
+func (entityRepresentation) ImplementsGraphQLType(name string) bool {
+	return name == "_Any"
+}
+
+func (r *entityRepresentation) UnmarshalGraphQL(input interface{}) error {
+	m, ok := input.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("wrong type for _Any: %T", input)
+	}
+	*r = m
+	return nil
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+func containsString(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]reflect.Value) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,232 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+)
+
+const subgraphSchema = `
+	type Query {
+		topProduct: Product
+	}
+
+	type Product @key(fields: "upc") @key(fields: "sku", resolvable: false) {
+		upc: String!
+		sku: String!
+		name: String @shareable
+	}
+
+	extend type User @key(fields: "id") {
+		id: ID! @external
+		reviewCount: Int!
+	}
+`
+
+type subgraphQuery struct{}
+
+func (*subgraphQuery) TopProduct() *subgraphProduct {
+	return &subgraphProduct{upc: "1", name: "Table"}
+}
+
+type subgraphProduct struct {
+	upc  string
+	name string
+}
+
+func (p *subgraphProduct) UPC() string   { return p.upc }
+func (p *subgraphProduct) SKU() string   { return "sku-" + p.upc }
+func (p *subgraphProduct) Name() *string { return &p.name }
+
+type subgraphUser struct {
+	ID graphql.ID
+}
+
+func (u *subgraphUser) ReviewCount() int32 { return int32(len(u.ID)) }
+
+var errBrokenProduct = errors.New("product is broken")
+
+func newSubgraphSchema(t *testing.T) *graphql.Schema {
+	s, err := graphql.ParseSchema(subgraphSchema, &subgraphQuery{},
+		graphql.UseFieldResolvers(),
+		graphql.EntityResolver("Product", func(ctx context.Context, rep map[string]interface{}) (*subgraphProduct, error) {
+			upc, _ := rep["upc"].(string)
+			if upc == "missing" {
+				return nil, nil
+			}
+			if upc == "broken" {
+				return nil, errBrokenProduct
+			}
+			return &subgraphProduct{upc: upc, name: "Product " + upc}, nil
+		}),
+		graphql.EntityResolver("User", func(ctx context.Context, rep struct{ ID graphql.ID }) (*subgraphUser, error) {
+			return &subgraphUser{ID: rep.ID}, nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestFederation(t *testing.T) {
+	s := newSubgraphSchema(t)
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: s,
+			Query: `
+				query($representations: [_Any!]!) {
+					_entities(representations: $representations) {
+						__typename
+						... on Product { upc name }
+						... on User { id reviewCount }
+					}
+				}
+			`,
+			Variables: map[string]interface{}{
+				"representations": []interface{}{
+					map[string]interface{}{"__typename": "Product", "upc": "2"},
+					map[string]interface{}{"__typename": "User", "id": "abc"},
+					map[string]interface{}{"__typename": "Product", "upc": "missing"},
+				},
+			},
+			ExpectedResult: `
+				{
+					"_entities": [
+						{"__typename": "Product", "upc": "2", "name": "Product 2"},
+						{"__typename": "User", "id": "abc", "reviewCount": 3},
+						null
+					]
+				}
+			`,
+		},
+		{
+			Schema: s,
+			Query: `
+				query($representations: [_Any!]!) {
+					_entities(representations: $representations) {
+						... on Product { upc }
+					}
+				}
+			`,
+			Variables: map[string]interface{}{
+				"representations": []interface{}{
+					map[string]interface{}{"__typename": "Product", "upc": "broken"},
+					map[string]interface{}{"__typename": "Product", "upc": "2"},
+					map[string]interface{}{"__typename": "Review", "id": "1"},
+				},
+			},
+			ExpectedResult: `
+				{
+					"_entities": [
+						null,
+						{"upc": "2"},
+						null
+					]
+				}
+			`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message:       "product is broken",
+					Locations:     []gqlerrors.Location{{Line: 3, Column: 6}},
+					Path:          []interface{}{"_entities", 0},
+					ResolverError: errBrokenProduct,
+				},
+				{
+					Message:       `unknown entity type "Review"`,
+					Locations:     []gqlerrors.Location{{Line: 3, Column: 6}},
+					Path:          []interface{}{"_entities", 2},
+					ResolverError: fmt.Errorf("unknown entity type %q", "Review"),
+				},
+			},
+		},
+		{
+			Schema: s,
+			Query: `
+				{
+					topProduct { name }
+				}
+			`,
+			ExpectedResult: `
+				{
+					"topProduct": {"name": "Table"}
+				}
+			`,
+		},
+	})
+
+	resp := s.Exec(context.Background(), `{ _service { sdl } }`, "", nil)
+	if len(resp.Errors) > 0 {
+		t.Fatal(resp.Errors)
+	}
+	if !strings.Contains(string(resp.Data), `extend type User @key(fields: \"id\")`) {
+		t.Errorf("_service.sdl does not contain the schema: %s", resp.Data)
+	}
+	if strings.Contains(string(resp.Data), "_entities") {
+		t.Errorf("_service.sdl must not contain the federation fields: %s", resp.Data)
+	}
+}
+
+func TestFederation_withoutEntities(t *testing.T) {
+	s := graphql.MustParseSchema(`type Query { hello: String! }`, &helloWorldResolver1{}, graphql.Federation())
+
+	if _, ok := s.ASTSchema().Types["_Entity"]; ok {
+		t.Error("_Entity must not be declared without entity types")
+	}
+	gqltesting.RunTest(t, &gqltesting.Test{
+		Schema: s,
+		Query:  `{ hello _service { sdl } }`,
+		ExpectedResult: `
+			{
+				"hello": "Hello world!",
+				"_service": {"sdl": "type Query { hello: String! }"}
+			}
+		`,
+	})
+}
+
+func TestFederation_invalid(t *testing.T) {
+	productResolver := func(ctx context.Context, rep map[string]interface{}) (*subgraphProduct, error) { return nil, nil }
+
+	for _, tt := range []struct {
+		name string
+		opts []graphql.SchemaOpt
+		want string
+	}{
+		{
+			name: "missing reference resolver",
+			opts: []graphql.SchemaOpt{graphql.Federation()},
+			want: `entity type "Product" has no reference resolver`,
+		},
+		{
+			name: "resolver for non-entity",
+			opts: []graphql.SchemaOpt{
+				graphql.EntityResolver("Product", productResolver),
+				graphql.EntityResolver("Query", productResolver),
+			},
+			want: `can not register reference resolver for "Query": not an object type with a resolvable @key directive`,
+		},
+		{
+			name: "invalid signature",
+			opts: []graphql.SchemaOpt{graphql.EntityResolver("Product", func(rep map[string]interface{}) *subgraphProduct { return nil })},
+			want: `reference resolver of "Product" must have the signature func(context.Context, R) (T, error), got func(map[string]interface {}) *graphql_test.subgraphProduct`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := graphql.ParseSchema(`
+				type Query { topProduct: Product }
+				type Product @key(fields: "upc") { upc: String! sku: String! name: String }
+			`, &subgraphQuery{}, tt.opts...)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("want error %q, got %v", tt.want, fmt.Sprint(err))
+			}
+		})
+	}
+}
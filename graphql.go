@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/graph-gophers/graphql-go/errors"
@@ -25,11 +26,69 @@ import (
 // the Go type signature of the resolvers does not match the schema. If nil is passed as the
 // resolver, then the schema can not be executed, but it may be inspected (e.g. with ToJSON).
 func ParseSchema(schemaString string, resolver interface{}, opts ...SchemaOpt) (*Schema, error) {
+	return ParseSchemaSources([]Source{{Body: schemaString}}, resolver, opts...)
+}
+
+// Source is a named GraphQL schema document, typically the contents of a single .graphql file.
+type Source struct {
+	// Name identifies the source in error messages, e.g. the path of the file.
+	Name string
+	// Body is the schema definition language text of the source.
+	Body string
+}
+
+// ParseSchemaSources parses a GraphQL schema split across several named sources and attaches the
+// given root resolver. The sources are combined as if they were a single document, so types may be
+// defined in one source and extended in another. Errors carry the name of the source in their
+// locations. See ParseSchema for the handling of the resolver.
+func ParseSchemaSources(sources []Source, resolver interface{}, opts ...SchemaOpt) (*Schema, error) {
+	s := newSchema(schema.New(), opts)
+
+	srcs := make([]schema.Source, len(sources))
+	for i, src := range sources {
+		srcs[i] = schema.Source{Name: src.Name, Body: src.Body}
+	}
+	if err := schema.Declare(s.schema, srcs, s.useStringDescriptions); err != nil {
+		return nil, err
+	}
+	if s.federation != nil {
+		if err := s.federation.extendSchema(s, srcs); err != nil {
+			return nil, err
+		}
+	}
+	if err := schema.Resolve(s.schema); err != nil {
+		return nil, err
+	}
+	if err := s.applyResolver(resolver); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewSchema creates a Schema from a type system that has been constructed programmatically, for
+// example with the builder package, and attaches the given root resolver. The types.Schema must
+// be fully resolved. Otherwise NewSchema behaves like ParseSchema.
+func NewSchema(typeSystem *types.Schema, resolver interface{}, opts ...SchemaOpt) (*Schema, error) {
+	s := newSchema(typeSystem, opts)
+	if s.federation != nil {
+		return nil, fmt.Errorf("federation is only supported for schemas parsed from SDL")
+	}
+	if err := s.applyResolver(resolver); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func newSchema(typeSystem *types.Schema, opts []SchemaOpt) *Schema {
 	s := &Schema{
-		schema:         schema.New(),
+		schema:         typeSystem,
 		maxParallelism: 10,
 		tracer:         trace.OpenTracingTracer{},
 		logger:         &log.DefaultLogger{},
+		funcs:          make(map[string]interface{}),
+		methods:        make(map[string]string),
+		resolverTypes:  make(map[string]reflect.Type),
+		enumValues:     make(map[string]interface{}),
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -42,21 +101,42 @@ func ParseSchema(schemaString string, resolver interface{}, opts ...SchemaOpt) (
 			s.validationTracer = &validationBridgingTracer{tracer: trace.NoopValidationTracer{}}
 		}
 	}
+	return s
+}
 
-	if err := schema.Parse(s.schema, schemaString, s.useStringDescriptions); err != nil {
-		return nil, err
-	}
+func (s *Schema) applyResolver(resolver interface{}) error {
 	if err := s.validateSchema(); err != nil {
-		return nil, err
+		return err
 	}
 
-	r, err := resolvable.ApplyResolver(s.schema, resolver)
+	concreteTypes := make(map[string]reflect.Type, len(s.resolverTypes))
+	for name, t := range s.resolverTypes {
+		concreteTypes[name] = t
+	}
+	if s.federation != nil {
+		entityTypes, err := s.federation.concreteTypes()
+		if err != nil {
+			return err
+		}
+		for name, t := range entityTypes {
+			if rt, ok := concreteTypes[name]; ok && rt != t {
+				return fmt.Errorf("resolver type %s of %q does not match the type %s returned by its reference resolver", rt, name, t)
+			}
+			concreteTypes[name] = t
+		}
+	}
+	opts := []resolvable.Option{
+		resolvable.WithFuncs(s.funcs),
+		resolvable.WithMethods(s.methods),
+		resolvable.WithConcreteTypes(concreteTypes),
+		resolvable.WithEnumValues(s.enumValues),
+	}
+	r, err := resolvable.ApplyResolver(s.schema, resolver, opts...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	s.res = r
-
-	return s, nil
+	return nil
 }
 
 // MustParseSchema calls ParseSchema and panics on error.
@@ -68,6 +148,15 @@ func MustParseSchema(schemaString string, resolver interface{}, opts ...SchemaOp
 	return s
 }
 
+// MustParseSchemaSources calls ParseSchemaSources and panics on error.
+func MustParseSchemaSources(sources []Source, resolver interface{}, opts ...SchemaOpt) *Schema {
+	s, err := ParseSchemaSources(sources, resolver, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
 // Schema represents a GraphQL schema with an optional resolver.
 type Schema struct {
 	schema *types.Schema
@@ -81,6 +170,16 @@ type Schema struct {
 	useStringDescriptions    bool
 	disableIntrospection     bool
 	subscribeResolverTimeout time.Duration
+	funcs                    map[string]interface{}
+	methods                  map[string]string
+	resolverTypes            map[string]reflect.Type
+	enumValues               map[string]interface{}
+	federation               *federation
+	visible                  VisibilityFunc
+	visibleSchemas           sync.Map
+	errorPresenter           ErrorPresenterFunc
+	maskInternalErrors       bool
+	allowError               func(err error) bool
 }
 
 func (s *Schema) ASTSchema() *types.Schema {
@@ -107,6 +206,50 @@ func UseFieldResolvers() SchemaOpt {
 	}
 }
 
+// ResolverFunc binds a Go function to the field with the given schema coordinate, e.g.
+// "User.fullName". The function is used instead of a method or struct field of the resolver and
+// has the signature
+//
+//	func([ctx context.Context], [source S], [args A]) (R, [error])
+//
+// The optional source parameter receives the resolver of the parent type, and args is the
+// argument struct of the field, which is required if the field has arguments.
+func ResolverFunc(coordinate string, fn interface{}) SchemaOpt {
+	return func(s *Schema) {
+		s.funcs[coordinate] = fn
+	}
+}
+
+// ResolverMethod binds the resolver method with the given name to the field with the given schema
+// coordinate, e.g. "User.id", instead of the method whose name matches the field name.
+func ResolverMethod(coordinate string, method string) SchemaOpt {
+	return func(s *Schema) {
+		s.methods[coordinate] = method
+	}
+}
+
+// ResolverType registers the Go type of resolver as the resolver type of the object type with the
+// given name, e.g. ResolverType("Human", (*humanResolver)(nil)). Interfaces and unions whose
+// resolver is a Go interface then resolve to the object type whose registered resolver type is
+// the dynamic type of the resolver, without a "ToHuman" method. A Go type registered for several
+// object types must have a method GraphQLTypeName() string returning the object type it resolves.
+func ResolverType(typeName string, resolver interface{}) SchemaOpt {
+	return func(s *Schema) {
+		s.resolverTypes[typeName] = reflect.TypeOf(resolver)
+	}
+}
+
+// EnumValues binds the enum with the given name to Go integer types. The values are a map from the
+// names of the enum values to integers, e.g. map[string]int32{"NEWHOPE": 0, "EMPIRE": 1} or the
+// value map generated for a protocol buffer enum. Each value of the enum must be mapped to a
+// distinct integer, and each name in the map must be a value of the enum. Resolvers and argument
+// structs may then use any Go integer type for the enum.
+func EnumValues(typeName string, values interface{}) SchemaOpt {
+	return func(s *Schema) {
+		s.enumValues[typeName] = values
+	}
+}
+
 // MaxDepth specifies the maximum field nesting depth in a query. The default is 0 which disables max depth checking.
 func MaxDepth(n int) SchemaOpt {
 	return func(s *Schema) {
@@ -143,6 +286,27 @@ func Logger(logger log.Logger) SchemaOpt {
 	}
 }
 
+// ErrorPresenter is applied to every error before it is added to the errors of a response, for
+// example to translate messages or to add extensions. It returns the error to report instead.
+func ErrorPresenter(presenter ErrorPresenterFunc) SchemaOpt {
+	return func(s *Schema) {
+		s.errorPresenter = presenter
+	}
+}
+
+// MaskInternalErrors hides the errors returned by resolvers and panics from clients, unless
+// allowed reports true for the error returned by the resolver. The message of a hidden error is
+// replaced with "internal server error" and its extensions with a random correlation ID in
+// "correlationId". The original error is logged with the correlation ID by the Logger if it
+// implements log.ErrorLogger, or else by the standard logger. A nil allowed hides all of these
+// errors.
+func MaskInternalErrors(allowed func(err error) bool) SchemaOpt {
+	return func(s *Schema) {
+		s.maskInternalErrors = true
+		s.allowError = allowed
+	}
+}
+
 // DisableIntrospection disables introspection queries.
 func DisableIntrospection() SchemaOpt {
 	return func(s *Schema) {
@@ -180,7 +344,8 @@ func (s *Schema) ValidateWithVariables(queryString string, variables map[string]
 		return []*errors.QueryError{qErr}
 	}
 
-	return validation.Validate(s.schema, doc, variables, s.maxDepth)
+	_, errs := validation.Validate(s.schema, doc, variables, s.maxDepth, s.res.UnmarshalScalar)
+	return errs
 }
 
 // Exec executes the given query with the schema's resolver. It panics if the schema was created
@@ -190,7 +355,9 @@ func (s *Schema) Exec(ctx context.Context, queryString string, operationName str
 	if s.res.Resolver == (reflect.Value{}) {
 		panic("schema created without resolver, can not exec")
 	}
-	return s.exec(ctx, queryString, operationName, variables, s.res)
+	resp := s.exec(ctx, queryString, operationName, variables, s.res)
+	resp.Errors = s.presentErrors(ctx, resp.Errors)
+	return resp
 }
 
 func (s *Schema) exec(ctx context.Context, queryString string, operationName string, variables map[string]interface{}, res *resolvable.Schema) *Response {
@@ -199,8 +366,9 @@ func (s *Schema) exec(ctx context.Context, queryString string, operationName str
 		return &Response{Errors: []*errors.QueryError{qErr}}
 	}
 
+	typeSystem := s.schemaFor(ctx)
 	validationFinish := s.validationTracer.TraceValidation(ctx)
-	errs := validation.Validate(s.schema, doc, variables, s.maxDepth)
+	coerced, errs := validation.Validate(typeSystem, doc, variables, s.maxDepth, res.UnmarshalScalar)
 	validationFinish(errs)
 	if len(errs) != 0 {
 		return &Response{Errors: errs}
@@ -227,30 +395,23 @@ func (s *Schema) exec(ctx context.Context, queryString string, operationName str
 		}
 	}
 
-	// Fill in variables with the defaults from the operation
-	if variables == nil {
-		variables = make(map[string]interface{}, len(op.Vars))
-	}
-	for _, v := range op.Vars {
-		if _, ok := variables[v.Name.Name]; !ok && v.Default != nil {
-			variables[v.Name.Name] = v.Default.Deserialize(nil)
-		}
-	}
+	variables = coerced[op]
 
 	r := &exec.Request{
 		Request: selected.Request{
 			Doc:                  doc,
 			Vars:                 variables,
-			Schema:               s.schema,
+			Schema:               typeSystem,
 			DisableIntrospection: s.disableIntrospection,
 		},
-		Limiter: make(chan struct{}, s.maxParallelism),
-		Tracer:  s.tracer,
-		Logger:  s.logger,
+		Limiter:       make(chan struct{}, s.maxParallelism),
+		Tracer:        s.tracer,
+		Logger:        s.logger,
+		InternalError: s.internalErrorFunc(),
 	}
 	varTypes := make(map[string]*introspection.Type)
 	for _, v := range op.Vars {
-		t, err := common.ResolveType(v.Type, s.schema.Resolve)
+		t, err := common.ResolveType(v.Type, typeSystem.Resolve)
 		if err != nil {
 			return &Response{Errors: []*errors.QueryError{err}}
 		}
@@ -261,8 +422,9 @@ func (s *Schema) exec(ctx context.Context, queryString string, operationName str
 	finish(errs)
 
 	return &Response{
-		Data:   data,
-		Errors: errs,
+		Data:       data,
+		Errors:     errs,
+		Extensions: warningsExtensions(r.Warnings),
 	}
 }
 
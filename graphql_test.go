@@ -2,12 +2,17 @@ package graphql_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/decode"
 	gqlerrors "github.com/graph-gophers/graphql-go/errors"
 	"github.com/graph-gophers/graphql-go/example/starwars"
 	"github.com/graph-gophers/graphql-go/gqltesting"
@@ -335,7 +340,7 @@ func TestRootOperations_invalidSchema(t *testing.T) {
 					}
 				`,
 			},
-			Want: want{Error: `graphql: type "Query" not found`},
+			Want: want{Error: `graphql: type "Query" not found (line 3, column 14)`},
 		},
 		"Query as incorrect type": {
 			Args: args{
@@ -383,7 +388,7 @@ func TestRootOperations_invalidSchema(t *testing.T) {
 					}
 				`,
 			},
-			Want: want{Error: `graphql: type "Mutation" not found`},
+			Want: want{Error: `graphql: type "Mutation" not found (line 4, column 17)`},
 		},
 	}
 
@@ -714,6 +719,7 @@ func TestNilInterface(t *testing.T) {
 			ExpectedErrors: []*gqlerrors.QueryError{
 				&gqlerrors.QueryError{
 					Message:       "x",
+					Locations:     []gqlerrors.Location{{Line: 4, Column: 6}},
 					Path:          []interface{}{"b"},
 					ResolverError: errors.New("x"),
 				},
@@ -752,6 +758,7 @@ func TestErrorPropagationInLists(t *testing.T) {
 			ExpectedErrors: []*gqlerrors.QueryError{
 				&gqlerrors.QueryError{
 					Message:       droidNotFoundError.Error(),
+					Locations:     []gqlerrors.Location{{Line: 4, Column: 7}},
 					Path:          []interface{}{"findDroids", 1, "name"},
 					ResolverError: droidNotFoundError,
 					Extensions:    map[string]interface{}{"code": droidNotFoundError.Code, "message": droidNotFoundError.Message},
@@ -794,6 +801,7 @@ func TestErrorPropagationInLists(t *testing.T) {
 			ExpectedErrors: []*gqlerrors.QueryError{
 				&gqlerrors.QueryError{
 					Message:       droidNotFoundError.Error(),
+					Locations:     []gqlerrors.Location{{Line: 4, Column: 7}},
 					Path:          []interface{}{"findDroids", 1, "name"},
 					ResolverError: droidNotFoundError,
 					Extensions:    map[string]interface{}{"code": droidNotFoundError.Code, "message": droidNotFoundError.Message},
@@ -827,8 +835,9 @@ func TestErrorPropagationInLists(t *testing.T) {
 			`,
 			ExpectedErrors: []*gqlerrors.QueryError{
 				&gqlerrors.QueryError{
-					Message: `graphql: got nil for non-null "Droid"`,
-					Path:    []interface{}{"findNilDroids", 1},
+					Message:   `graphql: got nil for non-null "Droid"`,
+					Locations: []gqlerrors.Location{{Line: 3, Column: 6}},
+					Path:      []interface{}{"findNilDroids", 1},
 				},
 			},
 		},
@@ -906,6 +915,7 @@ func TestErrorPropagationInLists(t *testing.T) {
 				&gqlerrors.QueryError{
 					Message:       quoteError.Error(),
 					ResolverError: quoteError,
+					Locations:     []gqlerrors.Location{{Line: 4, Column: 7}},
 					Path:          []interface{}{"findDroids", 0, "quotes"},
 				},
 			},
@@ -941,11 +951,13 @@ func TestErrorPropagationInLists(t *testing.T) {
 				&gqlerrors.QueryError{
 					Message:       quoteError.Error(),
 					ResolverError: quoteError,
+					Locations:     []gqlerrors.Location{{Line: 5, Column: 7}},
 					Path:          []interface{}{"findNilDroids", 0, "quotes"},
 				},
 				&gqlerrors.QueryError{
-					Message: `graphql: got nil for non-null "Droid"`,
-					Path:    []interface{}{"findNilDroids", 1},
+					Message:   `graphql: got nil for non-null "Droid"`,
+					Locations: []gqlerrors.Location{{Line: 3, Column: 6}},
+					Path:      []interface{}{"findNilDroids", 1},
 				},
 			},
 		},
@@ -984,6 +996,7 @@ func TestErrorWithExtensions(t *testing.T) {
 			ExpectedErrors: []*gqlerrors.QueryError{
 				&gqlerrors.QueryError{
 					Message:       droidNotFoundError.Error(),
+					Locations:     []gqlerrors.Location{{Line: 3, Column: 6}},
 					Path:          []interface{}{"FindDroid"},
 					ResolverError: droidNotFoundError,
 					Extensions:    map[string]interface{}{"code": droidNotFoundError.Code, "message": droidNotFoundError.Message},
@@ -1020,6 +1033,7 @@ func TestErrorWithNoExtensions(t *testing.T) {
 			ExpectedErrors: []*gqlerrors.QueryError{
 				&gqlerrors.QueryError{
 					Message:       err.Error(),
+					Locations:     []gqlerrors.Location{{Line: 3, Column: 6}},
 					Path:          []interface{}{"DismissVader"},
 					ResolverError: err,
 					Extensions:    nil,
@@ -1577,7 +1591,7 @@ func TestEnums(t *testing.T) {
 			Variables: map[string]interface{}{"episode": "FINAL_FRONTIER"},
 			ExpectedErrors: []*gqlerrors.QueryError{
 				{
-					Message:   "Variable \"episode\" has invalid value FINAL_FRONTIER.\nExpected type \"Episode\", found FINAL_FRONTIER.",
+					Message:   "Variable \"$episode\" got invalid value \"FINAL_FRONTIER\"; Value \"FINAL_FRONTIER\" does not exist in \"Episode\" enum.",
 					Locations: []gqlerrors.Location{{Column: 26, Line: 2}},
 					Rule:      "VariablesOfCorrectType",
 				},
@@ -1638,8 +1652,9 @@ func TestEnums(t *testing.T) {
 			}`,
 			ExpectedErrors: []*gqlerrors.QueryError{
 				{
-					Message: "Invalid value STAR_TREK.\nExpected type Episode, found STAR_TREK.",
-					Path:    []interface{}{"hero", "appearsIn", 0},
+					Message:   "Invalid value STAR_TREK.\nExpected type Episode, found STAR_TREK.",
+					Locations: []gqlerrors.Location{{Line: 5, Column: 7}},
+					Path:      []interface{}{"hero", "appearsIn", 0},
 				},
 			},
 		},
@@ -2474,6 +2489,26 @@ func TestIntrospection(t *testing.T) {
 											}
 										}
 									]
+								},
+								{
+									"name": "specifiedBy",
+									"description": "Exposes a URL that specifies the behavior of a custom scalar.",
+									"locations": [
+										"SCALAR"
+									],
+									"args": [
+										{
+											"name": "url",
+											"description": "The URL that specifies the behavior of this scalar.",
+											"type": {
+												"kind": "NON_NULL",
+												"ofType": {
+													"kind": "SCALAR",
+													"name": "String"
+												}
+											}
+										}
+									]
 								}
 							]
 						}
@@ -3195,7 +3230,7 @@ func TestInputArguments_failSchemaParsing(t *testing.T) {
 					}
 				`,
 			},
-			Want: want{Error: "field \"Input\": *graphql_test.helloInputMismatch does not define field \"name\" (hint: missing `args struct { ... }` wrapper for field arguments, or missing field on input struct)\n\tused by (*graphql_test.inputArgumentsObjectMismatch2).Hello"},
+			Want: want{Error: "*graphql_test.helloInputMismatch does not define field \"name\" (hint: missing `args struct { ... }` wrapper for field arguments, or missing field on input struct)\n\tused by (*graphql_test.inputArgumentsObjectMismatch2).Hello"},
 		},
 		"Inline Input struct missing field": {
 			Args: args{
@@ -3212,7 +3247,7 @@ func TestInputArguments_failSchemaParsing(t *testing.T) {
 					}
 				`,
 			},
-			Want: want{Error: "field \"Input\": *struct { Thing string } does not define field \"name\" (hint: missing `args struct { ... }` wrapper for field arguments, or missing field on input struct)\n\tused by (*graphql_test.inputArgumentsObjectMismatch3).Hello"},
+			Want: want{Error: "*struct { Thing string } does not define field \"name\" (hint: missing `args struct { ... }` wrapper for field arguments, or missing field on input struct)\n\tused by (*graphql_test.inputArgumentsObjectMismatch3).Hello"},
 		},
 	}
 
@@ -3232,6 +3267,77 @@ func TestInputArguments_failSchemaParsing(t *testing.T) {
 	}
 }
 
+type bindingErrorsQuery struct{}
+
+func (*bindingErrorsQuery) Hello(args struct{ Names string }) string { return "" }
+
+func (*bindingErrorsQuery) Friend() *bindingErrorsFriend { return nil }
+
+func (*bindingErrorsQuery) Search(args struct{ Filter *bindingErrorsFilter }) string { return "" }
+
+func (*bindingErrorsQuery) Count(args struct{ Limit int32 }) int32 { return args.Limit }
+
+type bindingErrorsFilter struct {
+	Name int32
+}
+
+type bindingErrorsFriend struct{}
+
+func (*bindingErrorsFriend) Age() (int32, string) { return 0, "" }
+
+func TestResolverBindingErrors(t *testing.T) {
+	_, err := graphql.ParseSchema(`
+		type Query {
+			hello(names: [String!]!, greeting: String): String!
+			friend: Friend
+			missing: Int
+			search(filter: Filter): String!
+			count(limit: Int = 1.5): Int!
+		}
+		type Friend {
+			name: String!
+			age: Int!
+		}
+		input Filter {
+			name: String
+			tag: String
+		}
+	`, &bindingErrorsQuery{})
+
+	errs, ok := err.(gqlerrors.BindingErrors)
+	if !ok {
+		t.Fatalf("expected gqlerrors.BindingErrors, got %T: %v", err, err)
+	}
+	type binding struct{ coordinate, goType string }
+	want := []binding{
+		{"Query.hello(names:)", "struct { Names string }"},
+		{"Query.hello(greeting:)", "struct { Names string }"},
+		{"Friend.name", "*graphql_test.bindingErrorsFriend"},
+		{"Friend.age", "*graphql_test.bindingErrorsFriend"},
+		{"Query.missing", "*graphql_test.bindingErrorsQuery"},
+		{"Filter.name", "*graphql_test.bindingErrorsFilter"},
+		{"Filter.tag", "*graphql_test.bindingErrorsFilter"},
+		{"Query.count(limit:)", "struct { Limit int32 }"},
+	}
+	var got []binding
+	for _, e := range errs {
+		got = append(got, binding{e.Coordinate, e.GoType.String()})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if !strings.HasPrefix(err.Error(), "8 resolver binding errors:\nQuery.hello(names:) (struct { Names string }): field \"Names\": expected slice, got string\n") {
+		t.Errorf("unexpected error message: %s", err)
+	}
+	if want := "Friend.age (*graphql_test.bindingErrorsFriend): must have \"error\" as its last return value\n\t\tused by (*graphql_test.bindingErrorsFriend).Age\n\t\tused by (*graphql_test.bindingErrorsQuery).Friend"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error message %q does not contain %q", err, want)
+	}
+	if want := "Query.count(limit:) (struct { Limit int32 }): invalid default value: could not unmarshal 1.5 (float64) into int32: not a 32-bit integer"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error message %q does not contain %q", err, want)
+	}
+}
+
 func TestComposedFragments(t *testing.T) {
 	gqltesting.RunTests(t, []*gqltesting.Test{
 		{
@@ -3337,6 +3443,7 @@ func TestErrorPropagation(t *testing.T) {
 				{
 					Message:       exampleError.Error(),
 					ResolverError: exampleError,
+					Locations:     []gqlerrors.Location{{Line: 4, Column: 6}},
 					Path:          []interface{}{"triggerError"},
 				},
 			},
@@ -3376,6 +3483,7 @@ func TestErrorPropagation(t *testing.T) {
 				{
 					Message:       exampleError.Error(),
 					ResolverError: exampleError,
+					Locations:     []gqlerrors.Location{{Line: 6, Column: 7}},
 					Path:          []interface{}{"child", "triggerError"},
 				},
 			},
@@ -3419,6 +3527,7 @@ func TestErrorPropagation(t *testing.T) {
 				{
 					Message:       exampleError.Error(),
 					ResolverError: exampleError,
+					Locations:     []gqlerrors.Location{{Line: 8, Column: 8}},
 					Path:          []interface{}{"child", "child", "triggerError"},
 				},
 			},
@@ -3465,6 +3574,7 @@ func TestErrorPropagation(t *testing.T) {
 				{
 					Message:       exampleError.Error(),
 					ResolverError: exampleError,
+					Locations:     []gqlerrors.Location{{Line: 8, Column: 8}},
 					Path:          []interface{}{"child", "child", "triggerError"},
 				},
 			},
@@ -3500,8 +3610,9 @@ func TestErrorPropagation(t *testing.T) {
 			`,
 			ExpectedErrors: []*gqlerrors.QueryError{
 				{
-					Message: nilChildErrorString,
-					Path:    []interface{}{"child", "nilChild"},
+					Message:   nilChildErrorString,
+					Locations: []gqlerrors.Location{{Line: 5, Column: 7}},
+					Path:      []interface{}{"child", "nilChild"},
 				},
 			},
 		},
@@ -3540,8 +3651,9 @@ func TestErrorPropagation(t *testing.T) {
 			`,
 			ExpectedErrors: []*gqlerrors.QueryError{
 				{
-					Message: nilChildErrorString,
-					Path:    []interface{}{"child", "nilChild"},
+					Message:   nilChildErrorString,
+					Locations: []gqlerrors.Location{{Line: 6, Column: 7}},
+					Path:      []interface{}{"child", "nilChild"},
 				},
 			},
 		},
@@ -3584,12 +3696,14 @@ func TestErrorPropagation(t *testing.T) {
 			`,
 			ExpectedErrors: []*gqlerrors.QueryError{
 				{
-					Message: nilChildErrorString,
-					Path:    []interface{}{"child", "child", "child", "nilChild"},
+					Message:   nilChildErrorString,
+					Locations: []gqlerrors.Location{{Line: 7, Column: 9}},
+					Path:      []interface{}{"child", "child", "child", "nilChild"},
 				},
 				{
 					Message:       exampleError.Error(),
 					ResolverError: exampleError,
+					Locations:     []gqlerrors.Location{{Line: 5, Column: 8}},
 					Path:          []interface{}{"child", "child", "triggerError"},
 				},
 			},
@@ -3628,8 +3742,9 @@ func TestErrorPropagation(t *testing.T) {
 			`,
 			ExpectedErrors: []*gqlerrors.QueryError{
 				{
-					Message: nilChildErrorString,
-					Path:    []interface{}{"child", "child", "nilChild"},
+					Message:   nilChildErrorString,
+					Locations: []gqlerrors.Location{{Line: 5, Column: 8}},
+					Path:      []interface{}{"child", "child", "nilChild"},
 				},
 			},
 		},
@@ -3810,8 +3925,9 @@ func TestPointerReturnForNonNull(t *testing.T) {
 			`,
 			ExpectedErrors: []*gqlerrors.QueryError{
 				{
-					Message: `graphql: got nil for non-null "Hello"`,
-					Path:    []interface{}{"pointerReturn", "value"},
+					Message:   `graphql: got nil for non-null "Hello"`,
+					Locations: []gqlerrors.Location{{Line: 4, Column: 7}},
+					Path:      []interface{}{"pointerReturn", "value"},
 				},
 			},
 		},
@@ -3999,3 +4115,950 @@ func TestNullable(t *testing.T) {
 		},
 	})
 }
+
+type resolverFuncUser struct {
+	first string
+	last  string
+}
+
+func (u *resolverFuncUser) First() string { return u.first }
+
+type resolverFuncQuery struct{}
+
+func (r *resolverFuncQuery) User() *resolverFuncUser {
+	return &resolverFuncUser{first: "Ada", last: "Lovelace"}
+}
+
+func TestResolverFunc(t *testing.T) {
+	t.Parallel()
+
+	schemaString := `
+		type Query {
+			user: User!
+			version: String!
+		}
+
+		type User {
+			first: String!
+			fullName(separator: String = " "): String!
+		}
+	`
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: graphql.MustParseSchema(schemaString, &resolverFuncQuery{},
+				graphql.ResolverFunc("Query.version", func(ctx context.Context) (string, error) {
+					return "1.0", nil
+				}),
+				graphql.ResolverFunc("User.fullName", func(u *resolverFuncUser, args struct{ Separator string }) string {
+					return u.first + args.Separator + u.last
+				}),
+			),
+			Query: `
+				{
+					version
+					user {
+						first
+						fullName
+						dashed: fullName(separator: "-")
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"version": "1.0",
+					"user": {
+						"first": "Ada",
+						"fullName": "Ada Lovelace",
+						"dashed": "Ada-Lovelace"
+					}
+				}
+			`,
+		},
+	})
+}
+
+func TestResolverFunc_invalid(t *testing.T) {
+	schemaString := `
+		type Query {
+			user: User!
+		}
+
+		type User {
+			first: String!
+		}
+	`
+	for name, test := range map[string]struct {
+		opt graphql.SchemaOpt
+		err string
+	}{
+		"unknown field": {
+			opt: graphql.ResolverFunc("User.last", func() string { return "" }),
+			err: `can not bind function to "User.last": type "User" has no field "last"`,
+		},
+		"not a function": {
+			opt: graphql.ResolverFunc("User.first", "Ada"),
+			err: `can not bind string to "User.first": not a function`,
+		},
+		"wrong source type": {
+			opt: graphql.ResolverFunc("User.first", func(q *resolverFuncQuery) string { return "" }),
+			err: "source parameter of type *graphql_test.resolverFuncQuery can not be used for *graphql_test.resolverFuncUser\n\tused by function bound to User.first\n\tused by (*graphql_test.resolverFuncQuery).User",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := graphql.ParseSchema(schemaString, &resolverFuncQuery{}, test.opt)
+			if err == nil {
+				t.Fatalf("want error %q, have <nil>", test.err)
+			}
+			if err.Error() != test.err {
+				t.Fatalf("want error %q, have %q", test.err, err)
+			}
+		})
+	}
+}
+
+type taggedAccount struct {
+	Identifier graphql.ID `graphql:"id"`
+	Name       string
+	NAME       string `graphql:"-"`
+	Secret     string `graphql:"-"`
+}
+
+type taggedQuery struct{}
+
+func (*taggedQuery) Lookup(args struct {
+	Key    graphql.ID `graphql:"id"`
+	Secret string     `graphql:"-"`
+	Limit  *int32
+}) *taggedAccount {
+	return &taggedAccount{Identifier: args.Key, Name: "Ada", NAME: "ignored"}
+}
+
+func (*taggedQuery) CurrentVersion() string { return "1.0" }
+
+func (*taggedQuery) Find(args struct {
+	ID  graphql.ID
+	Key graphql.ID `graphql:"id"`
+}) *taggedAccount {
+	return &taggedAccount{Identifier: args.Key, Name: string(args.ID)}
+}
+
+type renameBase struct {
+	Name string
+	Note string
+}
+
+// Rename receives "name" in its own field, which shadows the field of the embedded struct.
+func (*taggedQuery) Rename(args struct {
+	renameBase
+	Name string
+}) string {
+	return args.Name + "/" + args.renameBase.Name + "/" + args.Note
+}
+
+func (*taggedQuery) Count(args struct {
+	Limit *int32
+	LIMIT *int32
+}) int32 {
+	return 0
+}
+
+func TestStructTags(t *testing.T) {
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: graphql.MustParseSchema(`
+				type Query {
+					lookup(id: ID!, limit: Int): Account!
+					find(id: ID!): Account!
+					rename(name: String!, note: String!): String!
+					version: String!
+				}
+				type Account {
+					id: ID!
+					name: String!
+				}
+			`, &taggedQuery{}, graphql.UseFieldResolvers(), graphql.ResolverMethod("Query.version", "CurrentVersion")),
+			Query: `
+				{
+					lookup(id: "42") { id name }
+					find(id: "7") { id }
+					rename(name: "Bob", note: "n")
+					version
+				}
+			`,
+			ExpectedResult: `
+				{
+					"lookup": {"id": "42", "name": "Ada"},
+					"find": {"id": "7"},
+					"rename": "Bob//n",
+					"version": "1.0"
+				}
+			`,
+		},
+	})
+
+	for name, test := range map[string]struct {
+		schema string
+		opts   []graphql.SchemaOpt
+		err    string
+	}{
+		"excluded field": {
+			schema: `type Query { lookup(id: ID!): Account! } type Account { secret: String! }`,
+			opts:   []graphql.SchemaOpt{graphql.UseFieldResolvers()},
+			err:    "*graphql_test.taggedAccount does not resolve \"Account\": missing method for field \"secret\"\n\tused by (*graphql_test.taggedQuery).Lookup",
+		},
+		"excluded argument": {
+			schema: `type Query { lookup(id: ID!, secret: String): Account! } type Account { id: ID! }`,
+			opts:   []graphql.SchemaOpt{graphql.UseFieldResolvers()},
+			err:    "struct { Key graphql.ID \"graphql:\\\"id\\\"\"; Secret string \"graphql:\\\"-\\\"\"; Limit *int32 } does not define field \"secret\" (hint: missing `args struct { ... }` wrapper for field arguments, or missing field on input struct)\n\tused by (*graphql_test.taggedQuery).Lookup",
+		},
+		"ambiguous argument": {
+			schema: `type Query { count(limit: Int): Int! }`,
+			err:    "struct { Limit *int32; LIMIT *int32 } defines several fields for \"limit\"\n\tused by (*graphql_test.taggedQuery).Count",
+		},
+		"unknown method": {
+			schema: `type Query { version: String! }`,
+			opts:   []graphql.SchemaOpt{graphql.ResolverMethod("Query.version", "Version")},
+			err:    `*graphql_test.taggedQuery does not resolve "Query": missing method "Version" bound to field "version"`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := graphql.ParseSchema(test.schema, &taggedQuery{}, test.opts...)
+			if err == nil || err.Error() != test.err {
+				t.Fatalf("want error %q, have %v", test.err, err)
+			}
+		})
+	}
+}
+
+type searchResult interface{}
+
+type searchHuman struct{ name string }
+
+func (h *searchHuman) Name() string { return h.name }
+
+type searchVehicle struct {
+	kind string
+	name string
+}
+
+func (v *searchVehicle) GraphQLTypeName() string { return v.kind }
+func (v *searchVehicle) Name() string            { return v.name }
+func (v *searchVehicle) Model() string           { return "X-" + v.name }
+
+type searchQuery struct{}
+
+func (*searchQuery) Search() []searchResult {
+	return []searchResult{
+		&searchHuman{name: "Luke"},
+		&searchVehicle{kind: "Starship", name: "Falcon"},
+		&searchVehicle{kind: "Speeder", name: "Bike"},
+	}
+}
+
+func (*searchQuery) Vehicle() *searchVehicle {
+	return &searchVehicle{kind: "Speeder", name: "Bike"}
+}
+
+func TestResolverType(t *testing.T) {
+	const schema = `
+		type Query {
+			search: [SearchResult!]!
+			vehicle: Vehicle!
+		}
+		union SearchResult = Human | Starship | Speeder
+		union Vehicle = Starship | Speeder
+		type Human { name: String! }
+		type Starship { name: String! }
+		type Speeder { name: String! model: String! }
+	`
+	gqltesting.RunTest(t, &gqltesting.Test{
+		Schema: graphql.MustParseSchema(schema, &searchQuery{},
+			graphql.ResolverType("Human", (*searchHuman)(nil)),
+			graphql.ResolverType("Starship", (*searchVehicle)(nil)),
+			graphql.ResolverType("Speeder", (*searchVehicle)(nil)),
+		),
+		Query: `
+			{
+				search {
+					__typename
+					... on Human { name }
+					... on Starship { name }
+					... on Speeder { model }
+				}
+				vehicle {
+					__typename
+					... on Speeder { name }
+				}
+			}
+		`,
+		ExpectedResult: `
+			{
+				"search": [
+					{"__typename": "Human", "name": "Luke"},
+					{"__typename": "Starship", "name": "Falcon"},
+					{"__typename": "Speeder", "model": "X-Bike"}
+				],
+				"vehicle": {"__typename": "Speeder", "name": "Bike"}
+			}
+		`,
+	})
+
+	_, err := graphql.ParseSchema(schema, &searchQuery{}, graphql.ResolverType("Human", (*searchHuman)(nil)))
+	want := `graphql_test.searchResult does not resolve "SearchResult": missing method "ToStarship" to convert to "Starship" (hint: alternatively, register the resolver type of "Starship")`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("want error containing %q, got %v", want, err)
+	}
+
+	_, err = graphql.ParseSchema(schema, &searchQuery{},
+		graphql.ResolverType("Human", (*searchHuman)(nil)),
+		graphql.ResolverType("Starship", (*searchHuman)(nil)),
+		graphql.ResolverType("Speeder", (*searchVehicle)(nil)),
+	)
+	want = `*graphql_test.searchHuman resolves both "Human" and "Starship", so it must have a method GraphQLTypeName() string`
+	if err == nil || err.Error() != want {
+		t.Errorf("want error %q, got %v", want, err)
+	}
+}
+
+type temperature float64
+
+func (temperature) ImplementsGraphQLType(name string) bool { return name == "Temperature" }
+
+func (t *temperature) MarshalGraphQL() (interface{}, error) {
+	if *t < -273.15 {
+		return nil, fmt.Errorf("%v is below absolute zero", float64(*t))
+	}
+	return fmt.Sprintf("%.1f°C", float64(*t)), nil
+}
+
+type temperatureQuery struct{}
+
+func (*temperatureQuery) Readings() []*temperature {
+	t1, t2 := temperature(21.5), temperature(-300)
+	return []*temperature{&t1, &t2}
+}
+
+func (*temperatureQuery) Current() *temperature {
+	t := temperature(-300)
+	return &t
+}
+
+func TestScalarMarshaler(t *testing.T) {
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: graphql.MustParseSchema(`
+				scalar Temperature
+				type Query {
+					readings: [Temperature]!
+					current: Temperature
+				}
+			`, &temperatureQuery{}),
+			Query: `
+				{
+					readings
+					current
+				}
+			`,
+			ExpectedResult: `
+				{
+					"readings": ["21.5°C", null],
+					"current": null
+				}
+			`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message:       "could not marshal *graphql_test.temperature as Temperature: -300 is below absolute zero",
+					Locations:     []gqlerrors.Location{{Line: 3, Column: 6}},
+					Path:          []interface{}{"readings", 1},
+					ResolverError: fmt.Errorf("-300 is below absolute zero"),
+				},
+				{
+					Message:       "could not marshal *graphql_test.temperature as Temperature: -300 is below absolute zero",
+					Locations:     []gqlerrors.Location{{Line: 4, Column: 6}},
+					Path:          []interface{}{"current"},
+					ResolverError: fmt.Errorf("-300 is below absolute zero"),
+				},
+			},
+		},
+	})
+}
+
+type bigInt struct {
+	big.Int
+}
+
+func (bigInt) ImplementsGraphQLType(name string) bool { return name == "BigInt" }
+
+func (n *bigInt) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case json.Number:
+		return n.UnmarshalGraphQLLiteral("Int", string(input))
+	case string:
+		return n.UnmarshalGraphQLLiteral("String", input)
+	default:
+		return fmt.Errorf("wrong type for BigInt: %T", input)
+	}
+}
+
+func (n *bigInt) UnmarshalGraphQLLiteral(kind string, text string) error {
+	if kind != "Int" && kind != "String" {
+		return fmt.Errorf("wrong type for BigInt: %s", kind)
+	}
+	if _, ok := n.SetString(text, 10); !ok {
+		return fmt.Errorf("invalid BigInt %q", text)
+	}
+	return nil
+}
+
+type literalQuery struct{}
+
+func (*literalQuery) Double(args struct{ N bigInt }) string {
+	return new(big.Int).Mul(&args.N.Int, big.NewInt(2)).String()
+}
+
+func (*literalQuery) Half(args struct{ F float64 }) float64 {
+	return args.F / 2
+}
+
+func (*literalQuery) Echo(args struct{ ID graphql.ID }) graphql.ID {
+	return args.ID
+}
+
+func (*literalQuery) Triple(args struct{ I int32 }) int32 {
+	return args.I * 3
+}
+
+func TestScalarLiterals(t *testing.T) {
+	schema := graphql.MustParseSchema(`
+		scalar BigInt
+		type Query {
+			double(n: BigInt = 9223372036854775808): String!
+			half(f: Float!): Float!
+			echo(id: ID!): ID!
+			triple(i: Int!): Int!
+		}
+	`, &literalQuery{})
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: schema,
+			Query: `
+				{
+					literal: double(n: 9007199254740993)
+					default: double
+					half(f: 10000000000)
+					echo(id: 123456789012345678901234)
+				}
+			`,
+			ExpectedResult: `
+				{
+					"literal": "18014398509481986",
+					"default": "18446744073709551616",
+					"half": 5000000000,
+					"echo": "123456789012345678901234"
+				}
+			`,
+		},
+		{
+			Schema: schema,
+			Query: `
+				query($n: BigInt!, $f: Float!) {
+					double(n: $n)
+					half(f: $f)
+				}
+			`,
+			Variables: map[string]interface{}{
+				"n": json.Number("9007199254740993"),
+				"f": json.Number("1.5"),
+			},
+			ExpectedResult: `
+				{
+					"double": "18014398509481986",
+					"half": 0.75
+				}
+			`,
+		},
+		{
+			Schema: schema,
+			Query: `
+				{
+					half(f: 1)
+					echo(id: 2147483648)
+					double(n: 1.5)
+				}
+			`,
+			ExpectedResult: `
+				{
+					"half": 0.5,
+					"echo": "2147483648"
+				}
+			`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: "wrong type for BigInt: Float",
+				},
+			},
+		},
+		{
+			Schema: schema,
+			Query: `
+				query($a: Int!, $b: Int!) {
+					a: triple(i: $a)
+					b: triple(i: $b)
+				}
+			`,
+			Variables: map[string]interface{}{
+				"a": json.Number("1.0"),
+				"b": json.Number("1e2"),
+			},
+			ExpectedResult: `
+				{
+					"a": 3,
+					"b": 300
+				}
+			`,
+		},
+		{
+			Schema: schema,
+			Query: `
+				{
+					half(f: 10000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000)
+				}
+			`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message:   "Argument \"f\" has invalid value 10000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000.\nExpected type \"Float\", found 10000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000.",
+					Locations: []gqlerrors.Location{{Line: 3, Column: 14}},
+					Rule:      "ArgumentsOfCorrectType",
+				},
+			},
+		},
+	})
+}
+
+type protoEpisode int32
+
+const (
+	protoEpisodeNewHope protoEpisode = 0
+	protoEpisodeEmpire  protoEpisode = 1
+	protoEpisodeJedi    protoEpisode = 2
+)
+
+var protoEpisodeValue = map[string]int32{
+	"NEWHOPE": 0,
+	"EMPIRE":  1,
+	"JEDI":    2,
+}
+
+type shade uint8
+
+func (s shade) MarshalEnum() (string, error) {
+	switch s {
+	case 1:
+		return "LIGHT", nil
+	case 2:
+		return "DARK", nil
+	}
+	return "", fmt.Errorf("invalid shade %d", s)
+}
+
+func (s *shade) UnmarshalEnum(name string) error {
+	switch name {
+	case "LIGHT":
+		*s = 1
+	case "DARK":
+		*s = 2
+	default:
+		return fmt.Errorf("invalid shade %q", name)
+	}
+	return nil
+}
+
+type enumBindingQuery struct{}
+
+func (*enumBindingQuery) Episodes() []protoEpisode {
+	return []protoEpisode{protoEpisodeJedi, protoEpisodeNewHope}
+}
+
+func (*enumBindingQuery) Next(args struct{ Episode protoEpisode }) *protoEpisode {
+	if args.Episode == protoEpisodeJedi {
+		return nil
+	}
+	next := args.Episode + 1
+	return &next
+}
+
+func (*enumBindingQuery) Invalid() *protoEpisode {
+	e := protoEpisode(7)
+	return &e
+}
+
+func (*enumBindingQuery) Invert(args struct{ Shade shade }) shade { return 3 - args.Shade }
+
+func TestEnumBinding(t *testing.T) {
+	schema := `
+		enum Episode { NEWHOPE EMPIRE JEDI }
+		enum Shade { LIGHT DARK }
+		type Query {
+			episodes: [Episode!]!
+			next(episode: Episode!): Episode
+			invalid: Episode
+			invert(shade: Shade!): Shade!
+		}
+	`
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: graphql.MustParseSchema(schema, &enumBindingQuery{}, graphql.EnumValues("Episode", protoEpisodeValue)),
+			Query: `
+				query($episode: Episode!) {
+					episodes
+					next(episode: NEWHOPE)
+					last: next(episode: $episode)
+					invert(shade: DARK)
+				}
+			`,
+			Variables: map[string]interface{}{"episode": "JEDI"},
+			ExpectedResult: `
+				{
+					"episodes": ["JEDI", "NEWHOPE"],
+					"next": "EMPIRE",
+					"last": null,
+					"invert": "LIGHT"
+				}
+			`,
+		},
+		{
+			Schema: graphql.MustParseSchema(schema, &enumBindingQuery{}, graphql.EnumValues("Episode", protoEpisodeValue)),
+			Query: `
+				{
+					invalid
+				}
+			`,
+			ExpectedResult: `
+				{
+					"invalid": null
+				}
+			`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message:   "Invalid value 7.\nExpected type Episode, found 7.",
+					Locations: []gqlerrors.Location{{Line: 3, Column: 6}},
+					Path:      []interface{}{"invalid"},
+				},
+			},
+		},
+	})
+
+	for _, tt := range []struct {
+		values interface{}
+		err    string
+	}{
+		{map[string]int32{"NEWHOPE": 0, "EMPIRE": 1}, `can not bind values to enum "Episode": missing a value for "JEDI"`},
+		{map[string]int32{"NEWHOPE": 0, "EMPIRE": 1, "JEDI": 2, "CLONES": 3}, `can not bind values to enum "Episode": "CLONES" is not a value of the enum`},
+		{map[string]int32{"NEWHOPE": 0, "EMPIRE": 1, "JEDI": 1}, `can not bind values to enum "Episode": "EMPIRE" and "JEDI" are both bound to 1`},
+		{[]string{"NEWHOPE"}, `can not bind []string to enum "Episode": expected a map from strings to integers`},
+	} {
+		_, err := graphql.ParseSchema(schema, &enumBindingQuery{}, graphql.EnumValues("Episode", tt.values))
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("want error %q, got %v", tt.err, err)
+		}
+	}
+
+	_, err := graphql.ParseSchema(schema, &enumBindingQuery{})
+	want := `can not use graphql_test.protoEpisode as enum "Episode": register the values of the enum or add methods MarshalEnum and UnmarshalEnum`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("want error containing %q, got %v", want, err)
+	}
+
+	_, err = graphql.ParseSchema(`
+		enum Shade { LIGHT DARK DIM }
+		type Query {
+			invert(shade: Shade!): Shade!
+		}
+	`, &enumBindingQuery{})
+	want = `can not unmarshal enum value "DIM" into graphql_test.shade: invalid shade "DIM"`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("want error containing %q, got %v", want, err)
+	}
+}
+
+type userPatch struct {
+	Name struct {
+		decode.Optional
+		Value *string
+		Set   bool
+	}
+	Role struct {
+		decode.Optional
+		Value *string
+		Set   bool
+	}
+	Tags struct {
+		decode.Optional
+		Value *[]string
+		Set   bool
+	}
+	Address struct {
+		decode.Optional
+		Value *struct{ City *string }
+		Set   bool
+	}
+}
+
+type optionalQuery struct{}
+
+func (*optionalQuery) Update(args struct {
+	ID struct {
+		decode.Optional
+		Value *graphql.ID
+		Set   bool
+	}
+	Patch userPatch
+}) string {
+	p := args.Patch
+	var changes []string
+	if args.ID.Set {
+		changes = append(changes, fmt.Sprintf("id=%v", args.ID.Value != nil))
+	}
+	if p.Name.Set {
+		changes = append(changes, fmt.Sprintf("name=%v", p.Name.Value != nil))
+	}
+	if p.Role.Set {
+		changes = append(changes, fmt.Sprintf("role=%v", p.Role.Value != nil))
+	}
+	if p.Tags.Set {
+		changes = append(changes, fmt.Sprintf("tags=%v", p.Tags.Value != nil))
+	}
+	if p.Address.Set {
+		changes = append(changes, fmt.Sprintf("address=%v", p.Address.Value != nil))
+	}
+	return strings.Join(changes, " ")
+}
+
+type toggleQuery struct{}
+
+func (*toggleQuery) Toggle(args struct {
+	Input struct {
+		Value *int32
+		Set   bool
+	}
+}) string {
+	return fmt.Sprintf("value=%v set=%v", args.Input.Value != nil, args.Input.Set)
+}
+
+func TestOptionalInputs(t *testing.T) {
+	schema := graphql.MustParseSchema(`
+		enum Role { ADMIN USER }
+		input Address { city: String }
+		input UserPatch {
+			name: String
+			role: Role
+			tags: [String!]
+			address: Address
+		}
+		type Query {
+			update(id: ID, patch: UserPatch!): String!
+		}
+	`, &optionalQuery{})
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: schema,
+			Query: `
+				{
+					none: update(patch: {})
+					nulls: update(id: null, patch: {name: null, role: null, tags: null, address: null})
+					values: update(id: 1, patch: {name: "a", role: ADMIN, tags: ["b"], address: {city: "c"}})
+				}
+			`,
+			ExpectedResult: `
+				{
+					"none": "",
+					"nulls": "id=false name=false role=false tags=false address=false",
+					"values": "id=true name=true role=true tags=true address=true"
+				}
+			`,
+		},
+		{
+			Schema: schema,
+			Query: `
+				query($id: ID, $name: String, $patch: UserPatch!) {
+					missing: update(id: $id, patch: {name: $name})
+					object: update(patch: $patch)
+				}
+			`,
+			Variables: map[string]interface{}{
+				"patch": map[string]interface{}{"role": nil, "tags": []interface{}{"a"}},
+			},
+			ExpectedResult: `
+				{
+					"missing": "",
+					"object": "role=false tags=true"
+				}
+			`,
+		},
+		{
+			// a struct with the fields Value and Set is an input object unless marked by decode.Optional
+			Schema: graphql.MustParseSchema(`
+				input Toggle {
+					value: Int
+					set: Boolean!
+				}
+				type Query {
+					toggle(input: Toggle!): String!
+				}
+			`, &toggleQuery{}),
+			Query: `
+				{
+					toggle(input: {set: true})
+				}
+			`,
+			ExpectedResult: `
+				{
+					"toggle": "value=false set=true"
+				}
+			`,
+		},
+	})
+}
+
+type coercionQuery struct{}
+
+func (*coercionQuery) Order(args struct {
+	Input struct {
+		Items []struct {
+			Qty  int32
+			Note string
+		}
+		At *graphql.Time
+	}
+}) string {
+	var items []string
+	for _, item := range args.Input.Items {
+		items = append(items, fmt.Sprintf("%d:%s", item.Qty, item.Note))
+	}
+	return strings.Join(items, ",")
+}
+
+func (*coercionQuery) Reserve(args struct {
+	Items []struct{ ID graphql.ID }
+}) string {
+	return fmt.Sprintf("%d items", len(args.Items))
+}
+
+func TestVariableCoercion(t *testing.T) {
+	schema := graphql.MustParseSchema(`
+		scalar Time
+		input Item {
+			qty: Int!
+			note: String = "none"
+		}
+		input Order {
+			items: [Item!]!
+			at: Time
+		}
+		input Reservation {
+			id: ID!
+		}
+		type Query {
+			order(input: Order!): String!
+			reserve(items: [Reservation!]!): String!
+		}
+	`, &coercionQuery{})
+
+	query := `
+		query($input: Order!) {
+			order(input: $input)
+		}
+	`
+	loc := []gqlerrors.Location{{Line: 2, Column: 9}}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema:    schema,
+			Query:     query,
+			Variables: map[string]interface{}{"input": map[string]interface{}{"items": map[string]interface{}{"qty": json.Number("2")}}},
+			ExpectedResult: `
+				{
+					"order": "2:none"
+				}
+			`,
+		},
+		{
+			Schema: schema,
+			Query:  query,
+			Variables: map[string]interface{}{"input": map[string]interface{}{"items": []interface{}{
+				map[string]interface{}{"qty": 1},
+				map[string]interface{}{"qty": 2},
+				map[string]interface{}{"qty": "3"},
+			}}},
+			ExpectedErrors: []*gqlerrors.QueryError{{
+				Message:   `Variable "$input" got invalid value "3" at "input.items[2].qty"; Int cannot represent non-integer value: "3"`,
+				Locations: loc,
+				Rule:      "VariablesOfCorrectType",
+			}},
+		},
+		{
+			Schema:    schema,
+			Query:     query,
+			Variables: map[string]interface{}{"input": map[string]interface{}{"items": []interface{}{}, "size": 1}},
+			ExpectedErrors: []*gqlerrors.QueryError{{
+				Message:   `Variable "$input" got invalid value {"items":[],"size":1}; Field "size" is not defined by type "Order".`,
+				Locations: loc,
+				Rule:      "VariablesOfCorrectType",
+			}},
+		},
+		{
+			Schema:    schema,
+			Query:     query,
+			Variables: map[string]interface{}{"input": map[string]interface{}{"items": []interface{}{}, "at": "yesterday"}},
+			ExpectedErrors: []*gqlerrors.QueryError{{
+				Message:   `Variable "$input" got invalid value "yesterday" at "input.at"; Expected type "Time". parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+				Locations: loc,
+				Rule:      "VariablesOfCorrectType",
+			}},
+		},
+		{
+			Schema: schema,
+			Query:  query,
+			ExpectedErrors: []*gqlerrors.QueryError{{
+				Message:   `Variable "$input" of required type "Order!" was not provided.`,
+				Locations: loc,
+				Rule:      "VariablesOfCorrectType",
+			}},
+		},
+		{
+			// the value is a valid ID, but graphql.ID can not unmarshal an int
+			Schema: schema,
+			Query: `
+				query($items: [Reservation!]!) {
+					reserve(items: $items)
+				}
+			`,
+			Variables: map[string]interface{}{"items": []interface{}{
+				map[string]interface{}{"id": "1"},
+				map[string]interface{}{"id": 2},
+			}},
+			ExpectedResult: `{}`,
+			ExpectedErrors: []*gqlerrors.QueryError{{
+				Message:   `Variable "$items" got invalid value 2 at "items[1].id"; wrong type for ID: int`,
+				Locations: []gqlerrors.Location{{Line: 3, Column: 21}},
+			}},
+		},
+		{
+			Schema: schema,
+			Query: `
+				query($id: ID!) {
+					reserve(items: [{id: 1}, {id: $id}])
+				}
+			`,
+			Variables:      map[string]interface{}{"id": 2},
+			ExpectedResult: `{}`,
+			ExpectedErrors: []*gqlerrors.QueryError{{
+				Message:   `Variable "$id" got invalid value 2; wrong type for ID: int`,
+				Locations: []gqlerrors.Location{{Line: 3, Column: 36}},
+			}},
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package graphql
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 )
@@ -19,12 +20,23 @@ func (id *ID) UnmarshalGraphQL(input interface{}) error {
 		*id = ID(input)
 	case int32:
 		*id = ID(strconv.Itoa(int(input)))
+	case json.Number:
+		*id = ID(input)
 	default:
 		err = fmt.Errorf("wrong type for ID: %T", input)
 	}
 	return err
 }
 
+// UnmarshalGraphQLLiteral keeps the digits of integer literals which do not fit into an int32.
+func (id *ID) UnmarshalGraphQLLiteral(kind string, text string) error {
+	if kind != "String" && kind != "Int" {
+		return fmt.Errorf("wrong type for ID: %s", kind)
+	}
+	*id = ID(text)
+	return nil
+}
+
 func (id ID) MarshalJSON() ([]byte, error) {
 	return strconv.AppendQuote(nil, string(id)), nil
 }
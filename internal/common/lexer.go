@@ -26,10 +26,17 @@ type Ident struct {
 }
 
 func NewLexer(s string, useStringDescriptions bool) *Lexer {
+	return NewSourceLexer("", s, useStringDescriptions)
+}
+
+// NewSourceLexer returns a Lexer for the named source document. The name is recorded in every
+// location produced by the lexer, so errors can point at the document they originate from.
+func NewSourceLexer(name string, s string, useStringDescriptions bool) *Lexer {
 	sc := &scanner.Scanner{
 		Mode: scanner.ScanIdents | scanner.ScanInts | scanner.ScanFloats | scanner.ScanStrings,
 	}
 	sc.Init(strings.NewReader(s))
+	sc.Filename = name
 
 	l := Lexer{sc: sc, useStringDescriptions: useStringDescriptions}
 	l.sc.Error = l.CatchScannerError
@@ -123,7 +130,7 @@ func (l *Lexer) ConsumeIdentWithLoc() types.Ident {
 	loc := l.Location()
 	name := l.sc.TokenText()
 	l.ConsumeToken(scanner.Ident)
-	return types.Ident{name, loc}
+	return types.Ident{Name: name, Loc: loc}
 }
 
 func (l *Lexer) ConsumeKeyword(keyword string) {
@@ -163,6 +170,7 @@ func (l *Lexer) Location() errors.Location {
 	return errors.Location{
 		Line:   l.sc.Line,
 		Column: l.sc.Column,
+		Source: l.sc.Filename,
 	}
 }
 
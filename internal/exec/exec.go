@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"reflect"
 	"sync"
 	"time"
 
+	"github.com/graph-gophers/graphql-go/decode"
 	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/internal/exec/packer"
 	"github.com/graph-gophers/graphql-go/internal/exec/resolvable"
 	"github.com/graph-gophers/graphql-go/internal/exec/selected"
 	"github.com/graph-gophers/graphql-go/internal/query"
@@ -24,15 +27,32 @@ type Request struct {
 	Tracer                   trace.Tracer
 	Logger                   log.Logger
 	SubscribeResolverTimeout time.Duration
+
+	// InternalError is called with every error made from an error returned by a resolver or from
+	// a panic, in which case resolverErr is nil. It may replace the error, e.g. to hide internal
+	// details from clients.
+	InternalError func(ctx context.Context, err *errors.QueryError, resolverErr error) *errors.QueryError
+
+	// Warnings holds the warnings added by resolvers, which do not affect the data.
+	Warnings []*errors.QueryError
+
+	op *types.OperationDefinition
 }
 
 func (r *Request) handlePanic(ctx context.Context) {
 	if value := recover(); value != nil {
 		r.Logger.LogPanic(ctx, value)
-		r.AddError(makePanicError(value))
+		r.AddError(r.internalError(ctx, makePanicError(value), nil))
 	}
 }
 
+func (r *Request) internalError(ctx context.Context, err *errors.QueryError, resolverErr error) *errors.QueryError {
+	if r.InternalError == nil {
+		return err
+	}
+	return r.InternalError(ctx, err, resolverErr)
+}
+
 type extensionser interface {
 	Extensions() map[string]interface{}
 }
@@ -42,6 +62,7 @@ func makePanicError(value interface{}) *errors.QueryError {
 }
 
 func (r *Request) Execute(ctx context.Context, s *resolvable.Schema, op *types.OperationDefinition) ([]byte, []*errors.QueryError) {
+	r.op = op
 	var out bytes.Buffer
 	func() {
 		defer r.handlePanic(ctx)
@@ -56,9 +77,68 @@ func (r *Request) Execute(ctx context.Context, s *resolvable.Schema, op *types.O
 	return out.Bytes(), r.Errs
 }
 
+type fieldInfoKey struct{}
+
+// FieldInfo describes the field whose resolver received a context.
+type FieldInfo struct {
+	Field     *selected.SchemaField
+	Sels      []selected.Selection
+	Operation *types.OperationDefinition
+	Vars      map[string]interface{}
+	path      *pathSegment
+	locs      []errors.Location
+	req       *Request
+}
+
+// Path returns the path of the field in the response.
+func (i *FieldInfo) Path() []interface{} {
+	return i.path.toSlice()
+}
+
+// AddWarning adds a warning with the path and locations of the field to the request.
+func (i *FieldInfo) AddWarning(message string) {
+	w := &errors.QueryError{
+		Message:   message,
+		Locations: i.locs,
+		Path:      i.path.toSlice(),
+	}
+	i.req.Mu.Lock()
+	i.req.Warnings = append(i.req.Warnings, w)
+	i.req.Mu.Unlock()
+}
+
+// AddItemError adds the error of the item at index of the list returned by the resolver of the
+// field, which resolves the item to null instead of failing the whole field.
+func (i *FieldInfo) AddItemError(ctx context.Context, index int, resolverErr error) {
+	path := &pathSegment{i.path, index}
+	err := i.req.internalError(ctx, makeResolverError(resolverErr, path), resolverErr)
+	err.Locations = i.locs
+	i.req.AddError(err)
+}
+
+// withField returns the context passed to the resolver of the field f at the given path.
+func (r *Request) withField(ctx context.Context, f *fieldToExec, path *pathSegment) context.Context {
+	return context.WithValue(ctx, fieldInfoKey{}, &FieldInfo{
+		Field:     f.field,
+		Sels:      f.sels,
+		Operation: r.op,
+		Vars:      r.Vars,
+		path:      path,
+		locs:      f.locs,
+		req:       r,
+	})
+}
+
+// FieldInfoFromContext returns the field whose resolver received ctx, or nil.
+func FieldInfoFromContext(ctx context.Context) *FieldInfo {
+	info, _ := ctx.Value(fieldInfoKey{}).(*FieldInfo)
+	return info
+}
+
 type fieldToExec struct {
 	field    *selected.SchemaField
 	sels     []selected.Selection
+	locs     []errors.Location // locations of all selections of the field
 	resolver reflect.Value
 	out      *bytes.Buffer
 }
@@ -126,6 +206,7 @@ func collectFieldsToResolve(sels []selected.Selection, s *resolvable.Schema, res
 				*fields = append(*fields, field)
 			}
 			field.sels = append(field.sels, sel.Sels...)
+			field.locs = append(field.locs, sel.Loc)
 
 		case *selected.TypenameField:
 			_, ok := fieldByAlias[sel.Alias]
@@ -141,11 +222,11 @@ func collectFieldsToResolve(sels []selected.Selection, s *resolvable.Schema, res
 			}
 
 		case *selected.TypeAssertion:
-			out := resolver.Method(sel.MethodIndex).Call(nil)
-			if !out[1].Bool() {
+			out, ok := sel.Assert(resolver)
+			if !ok {
 				continue
 			}
-			collectFieldsToResolve(sel.Sels, s, out[0], fields, fieldByAlias)
+			collectFieldsToResolve(sel.Sels, s, out, fields, fieldByAlias)
 
 		default:
 			panic("unreachable")
@@ -158,8 +239,7 @@ func typeOf(tf *selected.TypenameField, resolver reflect.Value) string {
 		return tf.Name
 	}
 	for name, a := range tf.TypeAssertions {
-		out := resolver.Method(a.MethodIndex).Call(nil)
-		if out[1].Bool() {
+		if _, ok := a.Assert(resolver); ok {
 			return name
 		}
 	}
@@ -179,12 +259,15 @@ func execFieldSelection(ctx context.Context, r *Request, s *resolvable.Schema, f
 		finish(err)
 	}()
 
+	var moreErrs []*errors.QueryError // further errors of a resolver returning an errors.MultiError
 	err = func() (err *errors.QueryError) {
 		defer func() {
 			if panicValue := recover(); panicValue != nil {
-				r.Logger.LogPanic(ctx, panicValue)
+				fieldCtx := r.withField(ctx, f, path)
+				r.Logger.LogPanic(fieldCtx, panicValue)
 				err = makePanicError(panicValue)
 				err.Path = path.toSlice()
+				err = r.internalError(fieldCtx, err, nil)
 			}
 		}()
 
@@ -198,24 +281,25 @@ func execFieldSelection(ctx context.Context, r *Request, s *resolvable.Schema, f
 		}
 
 		res := f.resolver
-		if f.field.UseMethodResolver() {
+		if f.field.Dynamic {
+			var resolverErr error
+			result, resolverErr = f.field.ResolveDynamic(r.withField(traceCtx, f, path), res, f.field.Args)
+			if resolverErr != nil {
+				err, moreErrs = r.resolverErrors(r.withField(traceCtx, f, path), resolverErr, path)
+				return err
+			}
+		} else if f.field.UseMethodResolver() {
 			var in []reflect.Value
 			if f.field.HasContext {
-				in = append(in, reflect.ValueOf(traceCtx))
+				in = append(in, reflect.ValueOf(r.withField(traceCtx, f, path)))
 			}
 			if f.field.ArgsPacker != nil {
 				in = append(in, f.field.PackedArgs)
 			}
-			callOut := res.Method(f.field.MethodIndex).Call(in)
+			callOut := f.field.Call(res, in)
 			result = callOut[0]
 			if f.field.HasError && !callOut[1].IsNil() {
-				resolverErr := callOut[1].Interface().(error)
-				err := errors.Errorf("%s", resolverErr)
-				err.Path = path.toSlice()
-				err.ResolverError = resolverErr
-				if ex, ok := callOut[1].Interface().(extensionser); ok {
-					err.Extensions = ex.Extensions()
-				}
+				err, moreErrs = r.resolverErrors(r.withField(traceCtx, f, path), callOut[1].Interface().(error), path)
 				return err
 			}
 		} else {
@@ -235,15 +319,63 @@ func execFieldSelection(ctx context.Context, r *Request, s *resolvable.Schema, f
 	if err != nil {
 		// If an error occurred while resolving a field, it should be treated as though the field
 		// returned null, and an error must be added to the "errors" list in the response.
+		err.Locations = f.locs
 		r.AddError(err)
+		for _, err := range moreErrs {
+			err.Locations = f.locs
+			r.AddError(err)
+		}
 		f.out.WriteString("null")
 		return
 	}
 
-	r.execSelectionSet(traceCtx, f.sels, f.field.Type, path, s, result, f.out)
+	r.execSelectionSet(traceCtx, f.sels, f.field.Type, path, f.locs, s, result, f.out)
 }
 
-func (r *Request) execSelectionSet(ctx context.Context, sels []selected.Selection, typ types.Type, path *pathSegment, s *resolvable.Schema, resolver reflect.Value, out *bytes.Buffer) {
+// resolverErrors makes the errors of a field from the error returned by its resolver. An
+// errors.MultiError anywhere in the chain of the error results in one error per part, the first
+// of which is returned as err.
+func (r *Request) resolverErrors(ctx context.Context, resolverErr error, path *pathSegment) (err *errors.QueryError, more []*errors.QueryError) {
+	var multi errors.MultiError
+	if !stderrors.As(resolverErr, &multi) || len(multi) == 0 {
+		return r.internalError(ctx, makeResolverError(resolverErr, path), resolverErr), nil
+	}
+	for _, e := range multi[1:] {
+		more = append(more, r.internalError(ctx, makeResolverError(e, path), e))
+	}
+	return r.internalError(ctx, makeResolverError(multi[0], path), multi[0]), more
+}
+
+func makeResolverError(resolverErr error, path *pathSegment) *errors.QueryError {
+	err := errors.Errorf("%s", resolverErr)
+	err.Path = path.toSlice()
+	err.ResolverError = resolverErr
+	err.Extensions = extensions(resolverErr)
+	return err
+}
+
+// extensions returns the extensions of the first error in the chain of err which provides them,
+// either by an Extensions method or as a QueryError.
+func extensions(err error) map[string]interface{} {
+	for err != nil {
+		switch e := err.(type) {
+		case extensionser:
+			return e.Extensions()
+		case *errors.QueryError:
+			if e.Extensions != nil {
+				return e.Extensions
+			}
+		}
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return nil
+		}
+		err = u.Unwrap()
+	}
+	return nil
+}
+
+func (r *Request) execSelectionSet(ctx context.Context, sels []selected.Selection, typ types.Type, path *pathSegment, locs []errors.Location, s *resolvable.Schema, resolver reflect.Value, out *bytes.Buffer) {
 	t, nonNull := unwrapNonNull(typ)
 
 	// a reflect.Value of a nil interface will show up as an Invalid value
@@ -254,6 +386,7 @@ func (r *Request) execSelectionSet(ctx context.Context, sels []selected.Selectio
 		if nonNull {
 			err := errors.Errorf("graphql: got nil for non-null %q", t)
 			err.Path = path.toSlice()
+			err.Locations = locs
 			r.AddError(err)
 		}
 		out.WriteString("null")
@@ -274,22 +407,28 @@ func (r *Request) execSelectionSet(ctx context.Context, sels []selected.Selectio
 
 	switch t := t.(type) {
 	case *types.List:
-		r.execList(ctx, sels, t, path, s, resolver, out)
+		r.execList(ctx, sels, t, path, locs, s, resolver, out)
 
 	case *types.ScalarTypeDefinition:
-		v := resolver.Interface()
-		data, err := json.Marshal(v)
+		data, err := marshalScalar(t, resolver)
 		if err != nil {
-			panic(errors.Errorf("could not marshal %v: %s", v, err))
+			err.Path = path.toSlice()
+			err.Locations = locs
+			r.AddError(err)
+			out.WriteString("null")
+			return
 		}
 		out.Write(data)
 
 	case *types.EnumTypeDefinition:
-		var stringer fmt.Stringer = resolver
-		if s, ok := resolver.Interface().(fmt.Stringer); ok {
-			stringer = s
+		name, err := marshalEnum(t, s, resolver)
+		if err != nil {
+			err.Path = path.toSlice()
+			err.Locations = locs
+			r.AddError(err)
+			out.WriteString("null")
+			return
 		}
-		name := stringer.String()
 		var valid bool
 		for _, v := range t.EnumValuesDefinition {
 			if v.EnumValue == name {
@@ -300,6 +439,7 @@ func (r *Request) execSelectionSet(ctx context.Context, sels []selected.Selectio
 		if !valid {
 			err := errors.Errorf("Invalid value %s.\nExpected type %s, found %s.", name, t.Name, name)
 			err.Path = path.toSlice()
+			err.Locations = locs
 			r.AddError(err)
 			out.WriteString("null")
 			return
@@ -313,7 +453,73 @@ func (r *Request) execSelectionSet(ctx context.Context, sels []selected.Selectio
 	}
 }
 
-func (r *Request) execList(ctx context.Context, sels []selected.Selection, typ *types.List, path *pathSegment, s *resolvable.Schema, resolver reflect.Value, out *bytes.Buffer) {
+func marshalScalar(t *types.ScalarTypeDefinition, resolver reflect.Value) ([]byte, *errors.QueryError) {
+	v := resolver.Interface()
+	m, ok := v.(decode.Marshaler)
+	if !ok && resolver.CanAddr() {
+		m, ok = resolver.Addr().Interface().(decode.Marshaler)
+	}
+	if ok {
+		var marshalErr error
+		if v, marshalErr = m.MarshalGraphQL(); marshalErr != nil {
+			err := errors.Errorf("could not marshal %T as %s: %s", m, t.Name, marshalErr)
+			err.ResolverError = marshalErr
+			return nil, err
+		}
+	}
+	data, marshalErr := json.Marshal(v)
+	if marshalErr != nil {
+		err := errors.Errorf("could not marshal %T as %s: %s", v, t.Name, marshalErr)
+		err.ResolverError = marshalErr
+		return nil, err
+	}
+	return data, nil
+}
+
+// marshalEnum returns the name of the enum value of resolver, using the methods of decode.EnumMarshaler,
+// the registered values of the enum for integers, a String method or the underlying string.
+func marshalEnum(t *types.EnumTypeDefinition, s *resolvable.Schema, resolver reflect.Value) (string, *errors.QueryError) {
+	m, ok := resolver.Interface().(decode.EnumMarshaler)
+	if !ok && resolver.CanAddr() {
+		m, ok = resolver.Addr().Interface().(decode.EnumMarshaler)
+	}
+	if ok {
+		name, marshalErr := m.MarshalEnum()
+		if marshalErr != nil {
+			err := errors.Errorf("could not marshal %T as %s: %s", m, t.Name, marshalErr)
+			err.ResolverError = marshalErr
+			return "", err
+		}
+		return name, nil
+	}
+
+	if names, ok := s.EnumNames[t.Name]; ok && packer.IsInteger(resolver.Type()) {
+		i := packer.EnumInt(resolver)
+		name, ok := names[i]
+		if !ok {
+			return "", errors.Errorf("Invalid value %d.\nExpected type %s, found %d.", i, t.Name, i)
+		}
+		return name, nil
+	}
+
+	var stringer fmt.Stringer = resolver
+	if str, ok := resolver.Interface().(fmt.Stringer); ok {
+		stringer = str
+	}
+	return stringer.String(), nil
+}
+
+func (r *Request) execList(ctx context.Context, sels []selected.Selection, typ *types.List, path *pathSegment, locs []errors.Location, s *resolvable.Schema, resolver reflect.Value, out *bytes.Buffer) {
+	// the values of dynamic objects are not checked when the schema is built
+	if k := resolver.Kind(); k != reflect.Slice && k != reflect.Array {
+		err := errors.Errorf("could not resolve %s as %s: expected a slice or an array", resolver.Type(), typ)
+		err.Path = path.toSlice()
+		err.Locations = locs
+		r.AddError(err)
+		out.WriteString("null")
+		return
+	}
+
 	l := resolver.Len()
 	entryouts := make([]bytes.Buffer, l)
 
@@ -327,7 +533,7 @@ func (r *Request) execList(ctx context.Context, sels []selected.Selection, typ *
 			go func(i int) {
 				defer func() { <-sem }()
 				defer r.handlePanic(ctx)
-				r.execSelectionSet(ctx, sels, typ.OfType, &pathSegment{path, i}, s, resolver.Index(i), &entryouts[i])
+				r.execSelectionSet(ctx, sels, typ.OfType, &pathSegment{path, i}, locs, s, resolver.Index(i), &entryouts[i])
 			}(i)
 		}
 		for i := 0; i < concurrency; i++ {
@@ -335,7 +541,7 @@ func (r *Request) execList(ctx context.Context, sels []selected.Selection, typ *
 		}
 	} else {
 		for i := 0; i < l; i++ {
-			r.execSelectionSet(ctx, sels, typ.OfType, &pathSegment{path, i}, s, resolver.Index(i), &entryouts[i])
+			r.execSelectionSet(ctx, sels, typ.OfType, &pathSegment{path, i}, locs, s, resolver.Index(i), &entryouts[i])
 		}
 	}
 
@@ -0,0 +1,363 @@
+package packer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// ConstraintError reports an input value violating the @constraint directive of its argument or
+// input field.
+type ConstraintError struct {
+	// Path leads from the argument to the value: names of arguments and input fields, and indices
+	// of list elements.
+	Path []interface{}
+	Err  error
+}
+
+func (err *ConstraintError) Error() string {
+	return fmt.Sprintf("invalid value for %q: %s", err.PathString(), err.Err)
+}
+
+// PathString formats the path like "input.tags[1]".
+func (err *ConstraintError) PathString() string {
+	return FormatPath(err.Path)
+}
+
+// ValueError reports an input value which can not be packed into its Go type.
+type ValueError struct {
+	// Path leads from the argument to the value like the path of a ConstraintError.
+	Path []interface{}
+	Err  error
+}
+
+func (err *ValueError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *ValueError) Unwrap() error {
+	return err.Err
+}
+
+// FormatPath formats a path of names and indices like "input.tags[1]".
+func FormatPath(path []interface{}) string {
+	var b strings.Builder
+	for _, p := range path {
+		switch p := p.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%d]", p)
+		default:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			fmt.Fprint(&b, p)
+		}
+	}
+	return b.String()
+}
+
+// prependPath adds a segment to the path of err, which becomes a ValueError unless it is a
+// ConstraintError.
+func prependPath(err error, segment interface{}) error {
+	switch err := err.(type) {
+	case *ConstraintError:
+		err.Path = append([]interface{}{segment}, err.Path...)
+		return err
+	case *ValueError:
+		err.Path = append([]interface{}{segment}, err.Path...)
+		return err
+	}
+	return &ValueError{Path: []interface{}{segment}, Err: err}
+}
+
+// constraint holds the arguments of a @constraint directive.
+type constraint struct {
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+	min       *float64
+	max       *float64
+	format    func(string) bool
+	formatArg string
+}
+
+var formats = map[string]func(string) bool{
+	"email": func(s string) bool {
+		a, err := mail.ParseAddress(s)
+		return err == nil && a.Name == "" && a.Address == s
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.IsAbs()
+	},
+	"uuid": regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`).MatchString,
+	"date": func(s string) bool {
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	},
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	},
+	"ipv4": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	},
+	"ipv6": func(s string) bool {
+		return net.ParseIP(s) != nil && strings.Contains(s, ":")
+	},
+}
+
+// makeConstraint reads the @constraint directive of an input value, if any.
+func makeConstraint(v *types.InputValueDefinition) (*constraint, error) {
+	d := v.Directives.Get("constraint")
+	if d == nil {
+		return nil, nil
+	}
+
+	c := &constraint{}
+	for _, arg := range d.Arguments {
+		value := arg.Value.Deserialize(nil)
+		if value == nil {
+			continue
+		}
+		switch arg.Name.Name {
+		case "minLength", "maxLength":
+			i, ok := value.(int32)
+			if !ok {
+				return nil, fmt.Errorf("@constraint argument %q must be an Int", arg.Name.Name)
+			}
+			n := int(i)
+			if arg.Name.Name == "minLength" {
+				c.minLength = &n
+			} else {
+				c.maxLength = &n
+			}
+		case "min", "max":
+			var f float64
+			switch value := value.(type) {
+			case int32:
+				f = float64(value)
+			case float64:
+				f = value
+			default:
+				return nil, fmt.Errorf("@constraint argument %q must be a Float", arg.Name.Name)
+			}
+			if arg.Name.Name == "min" {
+				c.min = &f
+			} else {
+				c.max = &f
+			}
+		case "pattern":
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("@constraint argument %q must be a String", arg.Name.Name)
+			}
+			re, err := regexp.Compile(s)
+			if err != nil {
+				return nil, fmt.Errorf("@constraint argument %q: %s", arg.Name.Name, err)
+			}
+			c.pattern = re
+		case "format":
+			s, _ := value.(string)
+			f, ok := formats[s]
+			if !ok {
+				return nil, fmt.Errorf("@constraint argument %q: unknown format %q", arg.Name.Name, s)
+			}
+			c.format, c.formatArg = f, s
+		default:
+			return nil, fmt.Errorf("unknown @constraint argument %q", arg.Name.Name)
+		}
+	}
+	return c, nil
+}
+
+// check checks a value before it is packed. The length constraints of a list apply to the list,
+// and all other constraints to its elements.
+func (c *constraint) check(value interface{}) error {
+	value = plainValue(value)
+	if list, ok := value.([]interface{}); ok {
+		if err := c.checkLength(len(list), "elements"); err != nil {
+			return &ConstraintError{Err: err}
+		}
+		for i, entry := range list {
+			if err := c.checkValue(entry, false); err != nil {
+				return &ConstraintError{Path: []interface{}{i}, Err: err}
+			}
+		}
+		return nil
+	}
+	if err := c.checkValue(value, true); err != nil {
+		return &ConstraintError{Err: err}
+	}
+	return nil
+}
+
+func (c *constraint) checkValue(value interface{}, checkLength bool) error {
+	switch value := value.(type) {
+	case string:
+		if checkLength {
+			if err := c.checkLength(utf8.RuneCountInString(value), "characters"); err != nil {
+				return err
+			}
+		}
+		if c.pattern != nil && !c.pattern.MatchString(value) {
+			return fmt.Errorf("must match the pattern %q", c.pattern)
+		}
+		if c.format != nil && !c.format(value) {
+			return fmt.Errorf("must be in the format %q", c.formatArg)
+		}
+	case int32:
+		return c.checkNumber(float64(value))
+	case int64:
+		return c.checkNumber(float64(value))
+	case float64:
+		return c.checkNumber(value)
+	case json.Number:
+		f, err := strconv.ParseFloat(string(value), 64)
+		if err != nil {
+			return err
+		}
+		return c.checkNumber(f)
+	}
+	return nil
+}
+
+func (c *constraint) checkLength(n int, unit string) error {
+	if c.minLength != nil && n < *c.minLength {
+		return fmt.Errorf("must have at least %d %s", *c.minLength, unit)
+	}
+	if c.maxLength != nil && n > *c.maxLength {
+		return fmt.Errorf("must have at most %d %s", *c.maxLength, unit)
+	}
+	return nil
+}
+
+func (c *constraint) checkNumber(f float64) error {
+	if c.min != nil && f < *c.min {
+		return fmt.Errorf("must be at least %v", *c.min)
+	}
+	if c.max != nil && f > *c.max {
+		return fmt.Errorf("must be at most %v", *c.max)
+	}
+	return nil
+}
+
+// ValuesChecker checks the @constraint directives of input values which are passed to resolvers
+// without being packed, i.e. the arguments of dynamic fields.
+type ValuesChecker struct {
+	values []*checkedValue
+	inputs map[string]*ValuesChecker
+}
+
+type checkedValue struct {
+	name       string
+	constraint *constraint
+	typ        types.Type
+}
+
+// MakeValuesChecker makes the checker of the arguments or input fields values. It returns nil if
+// there are no values.
+func (b *Builder) MakeValuesChecker(values types.ArgumentsDefinition) (*ValuesChecker, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return b.makeValuesChecker(values)
+}
+
+func (b *Builder) makeValuesChecker(values types.ArgumentsDefinition) (*ValuesChecker, error) {
+	c := &ValuesChecker{inputs: b.valuesCheckers}
+	var errs FieldErrors
+	for _, v := range values {
+		cons, err := makeConstraint(v)
+		if err != nil {
+			errs = errs.add(v.Name.Name, err)
+			continue
+		}
+		if err := b.makeInputCheckers(v.Type); err != nil {
+			errs = errs.add(v.Name.Name, err)
+			continue
+		}
+		c.values = append(c.values, &checkedValue{name: v.Name.Name, constraint: cons, typ: v.Type})
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return c, nil
+}
+
+// makeInputCheckers makes the checkers of the input objects used by t.
+func (b *Builder) makeInputCheckers(t types.Type) error {
+	switch t := t.(type) {
+	case *types.NonNull:
+		return b.makeInputCheckers(t.OfType)
+	case *types.List:
+		return b.makeInputCheckers(t.OfType)
+	case *types.InputObject:
+		if _, ok := b.valuesCheckers[t.Name]; ok {
+			return nil
+		}
+		b.valuesCheckers[t.Name] = nil
+		c, err := b.makeValuesChecker(t.Values)
+		if err != nil {
+			return &InputObjectError{Type: t.Name, Errs: err.(FieldErrors)}
+		}
+		b.valuesCheckers[t.Name] = c
+	}
+	return nil
+}
+
+// Check checks the given values, which are deserialized as for a DynamicObject.
+func (c *ValuesChecker) Check(values map[string]interface{}) error {
+	for _, v := range c.values {
+		value, ok := values[v.name]
+		if !ok || value == nil {
+			continue
+		}
+		if v.constraint != nil {
+			if err := v.constraint.check(value); err != nil {
+				return prependPath(err, v.name)
+			}
+		}
+		if err := c.checkType(v.typ, value); err != nil {
+			return prependPath(err, v.name)
+		}
+	}
+	return nil
+}
+
+func (c *ValuesChecker) checkType(t types.Type, value interface{}) error {
+	switch t := t.(type) {
+	case *types.NonNull:
+		return c.checkType(t.OfType, value)
+	case *types.List:
+		list, ok := value.([]interface{})
+		if !ok {
+			return c.checkType(t.OfType, value)
+		}
+		for i, entry := range list {
+			if entry == nil {
+				continue
+			}
+			if err := c.checkType(t.OfType, entry); err != nil {
+				return prependPath(err, i)
+			}
+		}
+	case *types.InputObject:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		return c.inputs[t.Name].Check(fields)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package packer
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graph-gophers/graphql-go/decode"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+var (
+	enumMarshalerType   = reflect.TypeOf((*decode.EnumMarshaler)(nil)).Elem()
+	enumUnmarshalerType = reflect.TypeOf((*decode.EnumUnmarshaler)(nil)).Elem()
+)
+
+// HasEnumMethods reports whether t converts itself from and to enum names with the methods
+// MarshalEnum and UnmarshalEnum.
+func HasEnumMethods(t reflect.Type) bool {
+	p := reflect.PtrTo(t)
+	return p.Implements(enumMarshalerType) && p.Implements(enumUnmarshalerType)
+}
+
+// CheckEnumMethods checks that every value of the enum is unmarshaled by the UnmarshalEnum method
+// of t and marshaled back to the same name by its MarshalEnum method.
+func CheckEnumMethods(enum *types.EnumTypeDefinition, t reflect.Type) error {
+	for _, v := range enum.EnumValuesDefinition {
+		p := reflect.New(t)
+		if err := p.Interface().(decode.EnumUnmarshaler).UnmarshalEnum(v.EnumValue); err != nil {
+			return fmt.Errorf("can not unmarshal enum value %q into %s: %s", v.EnumValue, t, err)
+		}
+		name, err := p.Interface().(decode.EnumMarshaler).MarshalEnum()
+		if err != nil {
+			return fmt.Errorf("can not marshal enum value %q of %s: %s", v.EnumValue, t, err)
+		}
+		if name != v.EnumValue {
+			return fmt.Errorf("enum value %q of %s is marshaled as %q", v.EnumValue, t, name)
+		}
+	}
+	return nil
+}
+
+// IsInteger reports whether t has an integer kind.
+func IsInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// EnumInt returns the integer value of v, which must have an integer kind.
+func EnumInt(v reflect.Value) int64 {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	default:
+		return v.Int()
+	}
+}
+
+func (b *Builder) makeEnumPacker(enum *types.EnumTypeDefinition, reflectType reflect.Type) (packer, error) {
+	if reflectType.Kind() == reflect.String {
+		return &ValuePacker{
+			ValueType: reflectType,
+		}, nil
+	}
+
+	if HasEnumMethods(reflectType) {
+		if err := CheckEnumMethods(enum, reflectType); err != nil {
+			return nil, err
+		}
+		return &enumPacker{enum: enum, valueType: reflectType}, nil
+	}
+
+	if values, ok := b.EnumValues[enum.Name]; ok && IsInteger(reflectType) {
+		return &enumPacker{enum: enum, valueType: reflectType, values: values}, nil
+	}
+
+	return nil, fmt.Errorf("wrong type, expected %s, an integer type with registered values of %q or methods MarshalEnum and UnmarshalEnum", reflect.String, enum.Name)
+}
+
+// enumPacker packs enum names into Go integers, either by the registered values of the enum or
+// by the UnmarshalEnum method of the Go type if values is nil.
+type enumPacker struct {
+	enum      *types.EnumTypeDefinition
+	valueType reflect.Type
+	values    map[string]int64
+}
+
+func (p *enumPacker) Pack(value interface{}) (reflect.Value, error) {
+	if l, ok := value.(*literal); ok {
+		value = l.value
+	}
+	if value == nil {
+		return reflect.Value{}, errors.Errorf("got null for non-null")
+	}
+	name, ok := value.(string)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("could not unmarshal %#v (%T) into %s: incompatible type", value, value, p.valueType)
+	}
+
+	v := reflect.New(p.valueType)
+	if p.values == nil {
+		if err := v.Interface().(decode.EnumUnmarshaler).UnmarshalEnum(name); err != nil {
+			return reflect.Value{}, err
+		}
+		return v.Elem(), nil
+	}
+
+	i, ok := p.values[name]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("invalid value %q for enum %q", name, p.enum.Name)
+	}
+	switch p.valueType.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.Elem().SetUint(uint64(i))
+	default:
+		v.Elem().SetInt(i)
+	}
+	return v.Elem(), nil
+}
@@ -0,0 +1,94 @@
+package packer
+
+import (
+	"text/scanner"
+
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// literal is a primitive value written in a query or a default value. It keeps the text of the
+// value for custom scalars implementing decode.LiteralUnmarshaler.
+type literal struct {
+	value interface{}
+	kind  string
+	text  string
+}
+
+// DeserializeLiteral is like Value.Deserialize, but the result keeps the text of the primitive
+// literals. It must only be passed to packers.
+func DeserializeLiteral(v types.Value, vars map[string]interface{}) interface{} {
+	switch v := v.(type) {
+	case *types.PrimitiveValue:
+		l := &literal{value: v.Deserialize(vars), text: v.Text}
+		switch v.Type {
+		case scanner.Int:
+			l.kind = "Int"
+		case scanner.Float:
+			l.kind = "Float"
+		case scanner.String:
+			l.kind = "String"
+			l.text = l.value.(string)
+		default:
+			l.kind = "Enum"
+			if _, ok := l.value.(bool); ok {
+				l.kind = "Boolean"
+			}
+		}
+		return l
+
+	case *types.ListValue:
+		list := make([]interface{}, len(v.Values))
+		for i, entry := range v.Values {
+			list[i] = DeserializeLiteral(entry, vars)
+		}
+		return list
+
+	case *types.ObjectValue:
+		fields := make(map[string]interface{}, len(v.Fields))
+		for _, f := range v.Fields {
+			if !IsGiven(f.Value, vars) {
+				continue
+			}
+			fields[f.Name.Name] = DeserializeLiteral(f.Value, vars)
+		}
+		return fields
+
+	default:
+		return v.Deserialize(vars)
+	}
+}
+
+// IsGiven reports whether v is a value other than a variable which is missing from vars. An
+// argument or input field with such a value is treated as if it was not given at all.
+func IsGiven(v types.Value, vars map[string]interface{}) bool {
+	if v, ok := v.(*types.Variable); ok {
+		_, ok := vars[v.Name]
+		return ok
+	}
+	return true
+}
+
+// plainValue removes the literal text from a value returned by DeserializeLiteral.
+func plainValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *literal:
+		return value.value
+
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, entry := range value {
+			list[i] = plainValue(entry)
+		}
+		return list
+
+	case map[string]interface{}:
+		fields := make(map[string]interface{}, len(value))
+		for name, entry := range value {
+			fields[name] = plainValue(entry)
+		}
+		return fields
+
+	default:
+		return value
+	}
+}
@@ -1,9 +1,11 @@
 package packer
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/graph-gophers/graphql-go/decode"
@@ -16,8 +18,17 @@ type packer interface {
 }
 
 type Builder struct {
-	packerMap     map[typePair]*packerMapEntry
-	structPackers []*StructPacker
+	// EnumValues maps the names of enums to the Go integer values of their values, which are used
+	// to pack enums into integer types.
+	EnumValues map[string]map[string]int64
+
+	// ScalarTypes maps the names of custom scalars to the Go types which unmarshal them in
+	// arguments and input fields.
+	ScalarTypes map[string][]reflect.Type
+
+	packerMap      map[typePair]*packerMapEntry
+	structPackers  []*StructPacker
+	valuesCheckers map[string]*ValuesChecker
 }
 
 type typePair struct {
@@ -28,35 +39,59 @@ type typePair struct {
 type packerMapEntry struct {
 	packer  packer
 	targets []*packer
+	err     error
 }
 
 func NewBuilder() *Builder {
 	return &Builder{
-		packerMap: make(map[typePair]*packerMapEntry),
+		EnumValues:     make(map[string]map[string]int64),
+		ScalarTypes:    make(map[string][]reflect.Type),
+		packerMap:      make(map[typePair]*packerMapEntry),
+		valuesCheckers: make(map[string]*ValuesChecker),
 	}
 }
 
-func (b *Builder) Finish() error {
+// DefaultError is a problem packing the default value of the input value Name into the struct
+// GoType, which receives the fields of the input object InputType or, if InputType is empty, the
+// arguments of a field.
+type DefaultError struct {
+	InputType string
+	GoType    reflect.Type
+	Packer    *StructPacker
+	Name      string
+	Err       error
+}
+
+// Finish completes the packers and packs the default values of all input values. The returned
+// errors list the default values which can not be packed.
+func (b *Builder) Finish() []*DefaultError {
 	for _, entry := range b.packerMap {
 		for _, target := range entry.targets {
 			*target = entry.packer
 		}
 	}
 
+	var errs []*DefaultError
 	for _, p := range b.structPackers {
 		p.defaultStruct = reflect.New(p.structType).Elem()
 		for _, f := range p.fields {
 			if defaultVal := f.field.Default; defaultVal != nil {
-				v, err := f.fieldPacker.Pack(defaultVal.Deserialize(nil))
+				v, err := f.fieldPacker.Pack(DeserializeLiteral(defaultVal, nil))
 				if err != nil {
-					return err
+					errs = append(errs, &DefaultError{
+						InputType: p.inputType,
+						GoType:    p.structType,
+						Packer:    p,
+						Name:      f.field.Name.Name,
+						Err:       err,
+					})
+					continue
 				}
 				p.defaultStruct.FieldByIndex(f.fieldIndex).Set(v)
 			}
 		}
 	}
-
-	return nil
+	return errs
 }
 
 func (b *Builder) assignPacker(target *packer, schemaType types.Type, reflectType reflect.Type) error {
@@ -68,14 +103,31 @@ func (b *Builder) assignPacker(target *packer, schemaType types.Type, reflectTyp
 		var err error
 		ref.packer, err = b.makePacker(schemaType, reflectType)
 		if err != nil {
+			// packers already referring to this one recursively are finished with an invalid packer
+			ref.packer, ref.err = &invalidPacker{err}, err
 			return err
 		}
 	}
+	if ref.err != nil {
+		return ref.err
+	}
 	ref.targets = append(ref.targets, target)
 	return nil
 }
 
 func (b *Builder) makePacker(schemaType types.Type, reflectType reflect.Type) (packer, error) {
+	if valueIndex, setIndex, ok := optionalFields(reflectType); ok {
+		p := &optionalPacker{
+			structType: reflectType,
+			valueIndex: valueIndex,
+			setIndex:   setIndex,
+		}
+		if err := b.assignPacker(&p.elem, schemaType, reflectType.Field(valueIndex).Type); err != nil {
+			return nil, fieldError("Value", err)
+		}
+		return p, nil
+	}
+
 	t, nonNull := unwrapNonNull(schemaType)
 	if !nonNull {
 		if reflectType.Kind() == reflect.Ptr {
@@ -119,6 +171,9 @@ func (b *Builder) makeNonNullPacker(schemaType types.Type, reflectType reflect.T
 		if !u.ImplementsGraphQLType(schemaType.String()) {
 			return nil, fmt.Errorf("can not unmarshal %s into %s", schemaType, reflectType)
 		}
+		if t, ok := schemaType.(*types.ScalarTypeDefinition); ok {
+			b.addScalarType(t.Name, reflectType)
+		}
 		return &unmarshalerPacker{
 			ValueType: reflectType,
 		}, nil
@@ -131,15 +186,13 @@ func (b *Builder) makeNonNullPacker(schemaType types.Type, reflectType reflect.T
 		}, nil
 
 	case *types.EnumTypeDefinition:
-		if reflectType.Kind() != reflect.String {
-			return nil, fmt.Errorf("wrong type, expected %s", reflect.String)
-		}
-		return &ValuePacker{
-			ValueType: reflectType,
-		}, nil
+		return b.makeEnumPacker(t, reflectType)
 
 	case *types.InputObject:
-		e, err := b.MakeStructPacker(t.Values, reflectType)
+		e, err := b.makeStructPacker(t.Name, t.Values, reflectType)
+		if fieldErrs, ok := err.(FieldErrors); ok {
+			return nil, &InputObjectError{Type: t.Name, GoType: reflectType, Errs: fieldErrs}
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -165,7 +218,22 @@ func (b *Builder) makeNonNullPacker(schemaType types.Type, reflectType reflect.T
 	}
 }
 
+func (b *Builder) addScalarType(name string, reflectType reflect.Type) {
+	for _, t := range b.ScalarTypes[name] {
+		if t == reflectType {
+			return
+		}
+	}
+	b.ScalarTypes[name] = append(b.ScalarTypes[name], reflectType)
+}
+
+// MakeStructPacker returns a packer of the arguments of a field into the struct typ. Problems with
+// the fields of typ are returned as FieldErrors.
 func (b *Builder) MakeStructPacker(values []*types.InputValueDefinition, typ reflect.Type) (*StructPacker, error) {
+	return b.makeStructPacker("", values, typ)
+}
+
+func (b *Builder) makeStructPacker(inputType string, values []*types.InputValueDefinition, typ reflect.Type) (*StructPacker, error) {
 	structType := typ
 	usePtr := false
 	if typ.Kind() == reflect.Ptr {
@@ -177,20 +245,32 @@ func (b *Builder) MakeStructPacker(values []*types.InputValueDefinition, typ ref
 	}
 
 	var fields []*structPackerField
+	var errs FieldErrors
 	for _, v := range values {
 		fe := &structPackerField{field: v}
-		fx := func(n string) bool {
-			return strings.EqualFold(stripUnderscore(n), stripUnderscore(v.Name.Name))
-		}
 
-		sf, ok := structType.FieldByNameFunc(fx)
-		if !ok {
-			return nil, fmt.Errorf("%s does not define field %q (hint: missing `args struct { ... }` wrapper for field arguments, or missing field on input struct)", typ, v.Name.Name)
+		index, ambiguous := FindField(structType, v.Name.Name, true)
+		if ambiguous {
+			errs = errs.add(v.Name.Name, fmt.Errorf("%s defines several fields for %q", typ, v.Name.Name))
+			continue
+		}
+		if index == nil {
+			errs = errs.add(v.Name.Name, fmt.Errorf("%s does not define field %q (hint: missing `args struct { ... }` wrapper for field arguments, or missing field on input struct)", typ, v.Name.Name))
+			continue
 		}
+		sf := structType.FieldByIndex(index)
 		if sf.PkgPath != "" {
-			return nil, fmt.Errorf("field %q must be exported", sf.Name)
+			errs = errs.add(v.Name.Name, fmt.Errorf("field %q must be exported", sf.Name))
+			continue
 		}
-		fe.fieldIndex = sf.Index
+		fe.fieldIndex = index
+
+		c, err := makeConstraint(v)
+		if err != nil {
+			errs = errs.add(v.Name.Name, err)
+			continue
+		}
+		fe.constraint = c
 
 		ft := v.Type
 		if v.Default != nil {
@@ -199,13 +279,18 @@ func (b *Builder) MakeStructPacker(values []*types.InputValueDefinition, typ ref
 		}
 
 		if err := b.assignPacker(&fe.fieldPacker, ft, sf.Type); err != nil {
-			return nil, fmt.Errorf("field %q: %s", sf.Name, err)
+			errs = errs.add(v.Name.Name, fieldError(sf.Name, err))
+			continue
 		}
 
 		fields = append(fields, fe)
 	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 
 	p := &StructPacker{
+		inputType:  inputType,
 		structType: structType,
 		usePtr:     usePtr,
 		fields:     fields,
@@ -214,7 +299,131 @@ func (b *Builder) MakeStructPacker(values []*types.InputValueDefinition, typ ref
 	return p, nil
 }
 
+// FindField returns the index of the field of the struct type t which receives or resolves the
+// schema element with the given name. Fields tagged `graphql:"name"` match exactly that name, the
+// names of untagged fields are matched case-insensitively, ignoring underscores, and fields tagged
+// `graphql:"-"` are ignored. If shadow is true, a field of an embedded struct is shadowed by a
+// matching field at a shallower depth like in Go selectors, as for the structs receiving input
+// values; otherwise matches at all depths are considered, as for resolvers. A tagged field wins
+// over untagged ones; if several fields remain, ambiguous is true.
+func FindField(t reflect.Type, name string, shadow bool) (index []int, ambiguous bool) {
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	var tagged, untagged [][]int
+	collectFields(t, name, nil, &tagged, &untagged)
+	if shadow {
+		depth := -1
+		for _, matches := range [][][]int{tagged, untagged} {
+			for _, m := range matches {
+				if depth == -1 || len(m) < depth {
+					depth = len(m)
+				}
+			}
+		}
+		tagged, untagged = atDepth(tagged, depth), atDepth(untagged, depth)
+	}
+	matches := tagged
+	if len(matches) == 0 {
+		matches = untagged
+	}
+	switch len(matches) {
+	case 0:
+		return nil, false
+	case 1:
+		return matches[0], false
+	default:
+		return nil, true
+	}
+}
+
+func atDepth(indices [][]int, depth int) [][]int {
+	var l [][]int
+	for _, index := range indices {
+		if len(index) == depth {
+			l = append(l, index)
+		}
+	}
+	return l
+}
+
+func collectFields(t reflect.Type, name string, index []int, tagged, untagged *[][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fieldIndex := append(index[:len(index):len(index)], i)
+		switch tag := sf.Tag.Get("graphql"); tag {
+		case "-":
+		case "":
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				collectFields(sf.Type, name, fieldIndex, tagged, untagged)
+			}
+			if strings.EqualFold(stripUnderscore(sf.Name), stripUnderscore(name)) {
+				*untagged = append(*untagged, fieldIndex)
+			}
+		default:
+			if tag == name {
+				*tagged = append(*tagged, fieldIndex)
+			}
+		}
+	}
+}
+
+// FieldError is a problem with the struct field receiving the input value Name.
+type FieldError struct {
+	Name string
+	Err  error
+}
+
+func (err *FieldError) Error() string {
+	return err.Err.Error()
+}
+
+// FieldErrors lists the problems with all fields of a struct, in the order of the input values.
+type FieldErrors []*FieldError
+
+func (errs FieldErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (errs FieldErrors) add(name string, err error) FieldErrors {
+	return append(errs, &FieldError{Name: name, Err: err})
+}
+
+// InputObjectError lists the problems with the fields of the struct GoType receiving the input
+// object Type. It is reported by the input value of that type, but describes the input object.
+type InputObjectError struct {
+	Type   string
+	GoType reflect.Type
+	Errs   FieldErrors
+}
+
+func (err *InputObjectError) Error() string {
+	return err.Errs.Error()
+}
+
+// fieldError adds the name of the struct field to err, unless err describes an input object.
+func fieldError(name string, err error) error {
+	if _, ok := err.(*InputObjectError); ok {
+		return err
+	}
+	return fmt.Errorf("field %q: %s", name, err)
+}
+
+// invalidPacker stands in for a packer which could not be made.
+type invalidPacker struct {
+	err error
+}
+
+func (p *invalidPacker) Pack(value interface{}) (reflect.Value, error) {
+	return reflect.Value{}, p.err
+}
+
 type StructPacker struct {
+	inputType     string
 	structType    reflect.Type
 	usePtr        bool
 	defaultStruct reflect.Value
@@ -225,6 +434,7 @@ type structPackerField struct {
 	field       *types.InputValueDefinition
 	fieldIndex  []int
 	fieldPacker packer
+	constraint  *constraint
 }
 
 func (p *StructPacker) Pack(value interface{}) (reflect.Value, error) {
@@ -237,9 +447,14 @@ func (p *StructPacker) Pack(value interface{}) (reflect.Value, error) {
 	v.Elem().Set(p.defaultStruct)
 	for _, f := range p.fields {
 		if value, ok := values[f.field.Name.Name]; ok {
+			if f.constraint != nil && value != nil {
+				if err := f.constraint.check(value); err != nil {
+					return reflect.Value{}, prependPath(err, f.field.Name.Name)
+				}
+			}
 			packed, err := f.fieldPacker.Pack(value)
 			if err != nil {
-				return reflect.Value{}, err
+				return reflect.Value{}, prependPath(err, f.field.Name.Name)
 			}
 			v.Elem().FieldByIndex(f.fieldIndex).Set(packed)
 		}
@@ -265,13 +480,58 @@ func (e *listPacker) Pack(value interface{}) (reflect.Value, error) {
 	for i := range list {
 		packed, err := e.elem.Pack(list[i])
 		if err != nil {
-			return reflect.Value{}, err
+			return reflect.Value{}, prependPath(err, i)
 		}
 		v.Index(i).Set(packed)
 	}
 	return v, nil
 }
 
+// optionalFields reports whether t is a struct marked by decode.Optional with the fields Value and
+// Set bool, which receives any input value and records whether the value was given, even if it
+// was null. It returns the indices of both fields. Types implementing decode.Unmarshaler are
+// excluded.
+func optionalFields(t reflect.Type) (valueIndex int, setIndex int, ok bool) {
+	if t.Kind() != reflect.Struct {
+		return 0, 0, false
+	}
+	if _, ok := reflect.New(t).Interface().(interface{ OptionalInput() }); !ok {
+		return 0, 0, false
+	}
+	if _, ok := reflect.New(t).Interface().(decode.Unmarshaler); ok {
+		return 0, 0, false
+	}
+	value, ok := t.FieldByName("Value")
+	if !ok || value.PkgPath != "" || len(value.Index) != 1 {
+		return 0, 0, false
+	}
+	set, ok := t.FieldByName("Set")
+	if !ok || set.PkgPath != "" || len(set.Index) != 1 || set.Type.Kind() != reflect.Bool {
+		return 0, 0, false
+	}
+	return value.Index[0], set.Index[0], true
+}
+
+// optionalPacker packs a given value, including null, into the Value field of a struct and sets
+// its Set field. A struct of an input value which is not given stays zero.
+type optionalPacker struct {
+	structType reflect.Type
+	valueIndex int
+	setIndex   int
+	elem       packer
+}
+
+func (p *optionalPacker) Pack(value interface{}) (reflect.Value, error) {
+	packed, err := p.elem.Pack(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	v := reflect.New(p.structType).Elem()
+	v.Field(p.valueIndex).Set(packed)
+	v.Field(p.setIndex).SetBool(true)
+	return v, nil
+}
+
 type nullPacker struct {
 	elemPacker packer
 	valueType  reflect.Type
@@ -302,6 +562,9 @@ type ValuePacker struct {
 }
 
 func (p *ValuePacker) Pack(value interface{}) (reflect.Value, error) {
+	if l, ok := value.(*literal); ok {
+		value = l.value
+	}
 	if value == nil {
 		return reflect.Value{}, errors.Errorf("got null for non-null")
 	}
@@ -323,7 +586,15 @@ func (p *unmarshalerPacker) Pack(value interface{}) (reflect.Value, error) {
 	}
 
 	v := reflect.New(p.ValueType)
-	if err := v.Interface().(decode.Unmarshaler).UnmarshalGraphQL(value); err != nil {
+	if l, ok := value.(*literal); ok {
+		if u, ok := v.Interface().(decode.LiteralUnmarshaler); ok {
+			if err := u.UnmarshalGraphQLLiteral(l.kind, l.text); err != nil {
+				return reflect.Value{}, err
+			}
+			return v.Elem(), nil
+		}
+	}
+	if err := v.Interface().(decode.Unmarshaler).UnmarshalGraphQL(plainValue(value)); err != nil {
 		return reflect.Value{}, err
 	}
 	return v.Elem(), nil
@@ -348,6 +619,19 @@ func unmarshalInput(typ reflect.Type, input interface{}) (interface{}, error) {
 				return nil, fmt.Errorf("not a 32-bit integer")
 			}
 			return coerced, nil
+		case int64:
+			if input < math.MinInt32 || input > math.MaxInt32 {
+				return nil, fmt.Errorf("not a 32-bit integer")
+			}
+			return int32(input), nil
+		case json.Number:
+			// like a float64, a number such as 1.0 or 1e2 is an integer
+			f, err := strconv.ParseFloat(string(input), 64)
+			coerced := int32(f)
+			if err != nil || f < math.MinInt32 || f > math.MaxInt32 || float64(coerced) != f {
+				return nil, fmt.Errorf("not a 32-bit integer")
+			}
+			return coerced, nil
 		}
 
 	case reflect.Float64:
@@ -356,6 +640,14 @@ func unmarshalInput(typ reflect.Type, input interface{}) (interface{}, error) {
 			return float64(input), nil
 		case int:
 			return float64(input), nil
+		case int64:
+			return float64(input), nil
+		case json.Number:
+			coerced, err := input.Float64()
+			if err != nil {
+				return nil, fmt.Errorf("not a float")
+			}
+			return coerced, nil
 		}
 
 	case reflect.String:
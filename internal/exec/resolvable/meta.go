@@ -33,8 +33,9 @@ func newMeta(s *types.Schema) *Meta {
 		panic(err)
 	}
 
-	if err := b.finish(); err != nil {
-		panic(err)
+	b.finish()
+	if len(b.errs) > 0 {
+		panic(b.errs)
 	}
 
 	fieldTypename := Field{
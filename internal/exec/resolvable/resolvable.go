@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/graph-gophers/graphql-go/decode"
+	"github.com/graph-gophers/graphql-go/errors"
 	"github.com/graph-gophers/graphql-go/internal/exec/packer"
 	"github.com/graph-gophers/graphql-go/types"
 )
@@ -18,6 +20,25 @@ type Schema struct {
 	Mutation     Resolvable
 	Subscription Resolvable
 	Resolver     reflect.Value
+
+	// EnumNames maps the names of enums bound to Go integer types to the names of their values
+	// by Go integer value.
+	EnumNames map[string]map[int64]string
+
+	// ScalarTypes maps the names of custom scalars to the Go types which unmarshal them in
+	// arguments and input fields.
+	ScalarTypes map[string][]reflect.Type
+}
+
+// UnmarshalScalar checks an input value of a custom scalar by unmarshaling it into each Go type
+// bound to the scalar.
+func (s *Schema) UnmarshalScalar(t *types.ScalarTypeDefinition, value interface{}) error {
+	for _, typ := range s.ScalarTypes[t.Name] {
+		if err := reflect.New(typ).Interface().(decode.Unmarshaler).UnmarshalGraphQL(value); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 type Resolvable interface {
@@ -38,17 +59,144 @@ type Field struct {
 	HasContext  bool
 	HasError    bool
 	ArgsPacker  *packer.StructPacker
+	ArgsChecker *packer.ValuesChecker
 	ValueExec   Resolvable
 	TraceLabel  string
+	Func        reflect.Value
+	HasSource   bool
+	Dynamic     bool
 }
 
 func (f *Field) UseMethodResolver() bool {
 	return len(f.FieldIndex) == 0
 }
 
+// ResolveDynamic resolves the field of a dynamic object, i.e. a map with string keys or a
+// DynamicObject. The result is an interface value, which is nil for a missing map entry.
+func (f *Field) ResolveDynamic(ctx context.Context, resolver reflect.Value, args map[string]interface{}) (reflect.Value, error) {
+	if resolver.Kind() == reflect.Interface {
+		resolver = resolver.Elem()
+	}
+	var v interface{}
+	if obj, ok := resolver.Interface().(dynamicObject); ok {
+		var err error
+		if v, err = obj.Resolve(ctx, f.Name, args); err != nil {
+			return reflect.Value{}, err
+		}
+	} else if isStringMap(resolver.Type()) {
+		if e := resolver.MapIndex(reflect.ValueOf(f.Name).Convert(resolver.Type().Key())); e.IsValid() {
+			v = e.Interface()
+		}
+	} else {
+		return reflect.Value{}, fmt.Errorf("can not resolve field %q of type %q on %s, expected a map or DynamicObject", f.Name, f.TypeName, resolver.Type())
+	}
+	return reflect.ValueOf(&v).Elem(), nil
+}
+
+// Call invokes the method or function resolving the field on the given parent resolver.
+func (f *Field) Call(resolver reflect.Value, in []reflect.Value) []reflect.Value {
+	if !f.Func.IsValid() {
+		return resolver.Method(f.MethodIndex).Call(in)
+	}
+	if f.HasSource {
+		i := 0
+		if f.HasContext {
+			i = 1
+		}
+		args := make([]reflect.Value, 0, len(in)+1)
+		args = append(args, in[:i]...)
+		args = append(args, resolver)
+		in = append(args, in[i:]...)
+	}
+	return f.Func.Call(in)
+}
+
 type TypeAssertion struct {
 	MethodIndex int
 	TypeExec    Resolvable
+	// GoType is set instead of MethodIndex if the abstract type is resolved by an interface value
+	// whose dynamic type is GoType.
+	GoType reflect.Type
+	// TypeName is set if the resolver must report the object type by a GraphQLTypeName method or,
+	// for maps, a "__typename" entry. It is set instead of MethodIndex or in addition to GoType.
+	TypeName string
+}
+
+// Assert converts the resolver of an abstract type to the resolver of the asserted object type
+// and reports whether the conversion succeeded.
+func (a *TypeAssertion) Assert(resolver reflect.Value) (reflect.Value, bool) {
+	if a.GoType == nil && a.TypeName == "" {
+		out := resolver.Method(a.MethodIndex).Call(nil)
+		return out[0], out[1].Bool()
+	}
+	if a.GoType != nil {
+		if resolver.Kind() == reflect.Interface {
+			resolver = resolver.Elem()
+		}
+		if !resolver.IsValid() || resolver.Type() != a.GoType {
+			return reflect.Value{}, false
+		}
+	}
+	if a.TypeName != "" && dynamicTypeName(resolver) != a.TypeName {
+		return reflect.Value{}, false
+	}
+	return resolver, true
+}
+
+// dynamicObject mirrors graphql.DynamicObject.
+type dynamicObject interface {
+	Resolve(ctx context.Context, field string, args map[string]interface{}) (interface{}, error)
+}
+
+// typeNamer is implemented by resolvers reporting the object type they resolve.
+type typeNamer interface {
+	GraphQLTypeName() string
+}
+
+var (
+	typeNamerType = reflect.TypeOf((*typeNamer)(nil)).Elem()
+	stringerType  = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// dynamicValue is the type bound to the fields of dynamic objects, whose values are only known at
+// execution time. It is distinct from interface{}, which is bound statically.
+type dynamicValue interface{}
+
+var dynamicObjectType = reflect.TypeOf((*dynamicObject)(nil)).Elem()
+var dynamicValueType = reflect.TypeOf((*dynamicValue)(nil)).Elem()
+
+// isDynamic reports whether values of type t are resolved at execution time instead of being
+// bound to the schema by reflection.
+func isDynamic(t reflect.Type) bool {
+	return t == dynamicValueType || isStringMap(t) || t.Implements(dynamicObjectType)
+}
+
+func isStringMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+// dynamicTypeName returns the object type reported by the resolver of an abstract type, which is
+// given by its GraphQLTypeName method or the "__typename" entry of a map.
+func dynamicTypeName(resolver reflect.Value) string {
+	if resolver.Kind() == reflect.Interface {
+		resolver = resolver.Elem()
+	}
+	if !resolver.IsValid() {
+		return ""
+	}
+	if n, ok := resolver.Interface().(typeNamer); ok {
+		return n.GraphQLTypeName()
+	}
+	if isStringMap(resolver.Type()) {
+		e := resolver.MapIndex(reflect.ValueOf("__typename").Convert(resolver.Type().Key()))
+		if e.Kind() == reflect.Interface {
+			e = e.Elem()
+		}
+		if e.Kind() == reflect.String {
+			return e.String()
+		}
+	}
+	return ""
 }
 
 type List struct {
@@ -61,35 +209,198 @@ func (*Object) isResolvable() {}
 func (*List) isResolvable()   {}
 func (*Scalar) isResolvable() {}
 
-func ApplyResolver(s *types.Schema, resolver interface{}) (*Schema, error) {
+// Option configures how ApplyResolver binds resolvers to the schema.
+type Option func(*execBuilder) error
+
+// WithFuncs binds Go functions to schema fields. The map is keyed by the schema coordinate of the
+// field ("Type.field"). A bound function takes precedence over methods and struct fields of the
+// resolver and has the signature
+//
+//	func([context.Context], [source], [args]) (value, [error])
+//
+// where source receives the parent resolver and args is the argument struct of the field.
+func WithFuncs(funcs map[string]interface{}) Option {
+	return func(b *execBuilder) error {
+		for coord, fn := range funcs {
+			if err := b.checkField(coord); err != nil {
+				return fmt.Errorf("can not bind function to %q: %s", coord, err)
+			}
+			v := reflect.ValueOf(fn)
+			if v.Kind() != reflect.Func {
+				return fmt.Errorf("can not bind %T to %q: not a function", fn, coord)
+			}
+			b.funcs[coord] = v
+		}
+		return nil
+	}
+}
+
+// WithMethods binds resolver methods to schema fields by name. The map is keyed by the schema
+// coordinate of the field ("Type.field") and holds the exact name of the Go method resolving it,
+// which is used instead of the method matching the field name.
+func WithMethods(methods map[string]string) Option {
+	return func(b *execBuilder) error {
+		for coord, name := range methods {
+			if err := b.checkField(coord); err != nil {
+				return fmt.Errorf("can not bind method %q to %q: %s", name, coord, err)
+			}
+			b.methods[coord] = name
+		}
+		return nil
+	}
+}
+
+// checkField checks that coord is the schema coordinate of a field of an object or interface type.
+func (b *execBuilder) checkField(coord string) error {
+	i := strings.IndexByte(coord, '.')
+	if i == -1 {
+		return fmt.Errorf("invalid field coordinate, expected \"Type.field\"")
+	}
+	typeName, fieldName := coord[:i], coord[i+1:]
+	var fields types.FieldsDefinition
+	switch t := b.schema.Types[typeName].(type) {
+	case *types.ObjectTypeDefinition:
+		fields = t.Fields
+	case *types.InterfaceTypeDefinition:
+		fields = t.Fields
+	default:
+		return fmt.Errorf("%q is not an object or interface type", typeName)
+	}
+	if fields.Get(fieldName) == nil {
+		return fmt.Errorf("type %q has no field %q", typeName, fieldName)
+	}
+	return nil
+}
+
+// WithConcreteTypes maps object types to the Go types of their resolvers. An abstract type whose
+// resolver is a Go interface without a "ToX" method for a possible type X resolves to X if the
+// dynamic type of the resolver is the Go type mapped to X and, if that Go type has a
+// GraphQLTypeName method, it returns "X".
+func WithConcreteTypes(concreteTypes map[string]reflect.Type) Option {
+	return func(b *execBuilder) error {
+		for name, t := range concreteTypes {
+			if t == nil {
+				return fmt.Errorf("can not map nil to %q", name)
+			}
+			if _, ok := b.schema.Types[name].(*types.ObjectTypeDefinition); !ok {
+				return fmt.Errorf("can not map %s to %q: not an object type", t, name)
+			}
+			b.concreteTypes[name] = t
+		}
+
+		names := make([]string, 0, len(b.concreteTypes))
+		for name := range b.concreteTypes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		objects := make(map[reflect.Type]string)
+		for _, name := range names {
+			t := b.concreteTypes[name]
+			if other, ok := objects[t]; ok && !t.Implements(typeNamerType) {
+				return fmt.Errorf("%s resolves both %q and %q, so it must have a method GraphQLTypeName() string", t, other, name)
+			}
+			objects[t] = name
+		}
+		return nil
+	}
+}
+
+// WithEnumValues binds enums to Go integer types. The map is keyed by the name of the enum and
+// holds a map from the names of the enum values to integers, such as the value map generated for
+// a protocol buffer enum. Each value of the enum must be mapped to a distinct integer and each
+// name in the map must be a value of the enum.
+func WithEnumValues(enumValues map[string]interface{}) Option {
+	return func(b *execBuilder) error {
+		for name, values := range enumValues {
+			enum, ok := b.schema.Types[name].(*types.EnumTypeDefinition)
+			if !ok {
+				return fmt.Errorf("can not bind values to %q: not an enum type", name)
+			}
+			m := reflect.ValueOf(values)
+			if m.Kind() != reflect.Map || m.Type().Key().Kind() != reflect.String || !packer.IsInteger(m.Type().Elem()) {
+				return fmt.Errorf("can not bind %T to enum %q: expected a map from strings to integers", values, name)
+			}
+
+			byName := make(map[string]int64, m.Len())
+			for _, k := range m.MapKeys() {
+				byName[k.String()] = packer.EnumInt(m.MapIndex(k))
+			}
+			valueNames := make([]string, 0, len(byName))
+			for valueName := range byName {
+				valueNames = append(valueNames, valueName)
+			}
+			sort.Strings(valueNames)
+
+			byValue := make(map[int64]string, len(byName))
+			for _, valueName := range valueNames {
+				if !hasEnumValue(enum, valueName) {
+					return fmt.Errorf("can not bind values to enum %q: %q is not a value of the enum", name, valueName)
+				}
+				i := byName[valueName]
+				if other, ok := byValue[i]; ok {
+					return fmt.Errorf("can not bind values to enum %q: %q and %q are both bound to %d", name, other, valueName, i)
+				}
+				byValue[i] = valueName
+			}
+			for _, v := range enum.EnumValuesDefinition {
+				if _, ok := byName[v.EnumValue]; !ok {
+					return fmt.Errorf("can not bind values to enum %q: missing a value for %q", name, v.EnumValue)
+				}
+			}
+
+			b.packerBuilder.EnumValues[name] = byName
+			b.enumNames[name] = byValue
+		}
+		return nil
+	}
+}
+
+func hasEnumValue(enum *types.EnumTypeDefinition, name string) bool {
+	for _, v := range enum.EnumValuesDefinition {
+		if v.EnumValue == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyResolver binds the resolver to the schema. If the resolver does not match the schema, the
+// returned error is an errors.BindingErrors describing every problem found.
+func ApplyResolver(s *types.Schema, resolver interface{}, opts ...Option) (*Schema, error) {
 	if resolver == nil {
 		return &Schema{Meta: newMeta(s), Schema: *s}, nil
 	}
 
 	b := newBuilder(s)
+	for _, opt := range opts {
+		if err := opt(b); err != nil {
+			return nil, err
+		}
+	}
 
 	var query, mutation, subscription Resolvable
 
 	if t, ok := s.EntryPoints["query"]; ok {
 		if err := b.assignExec(&query, t, reflect.TypeOf(resolver)); err != nil {
-			return nil, err
+			b.report(t.TypeName(), reflect.TypeOf(resolver), err)
 		}
 	}
 
 	if t, ok := s.EntryPoints["mutation"]; ok {
 		if err := b.assignExec(&mutation, t, reflect.TypeOf(resolver)); err != nil {
-			return nil, err
+			b.report(t.TypeName(), reflect.TypeOf(resolver), err)
 		}
 	}
 
 	if t, ok := s.EntryPoints["subscription"]; ok {
 		if err := b.assignExec(&subscription, t, reflect.TypeOf(resolver)); err != nil {
-			return nil, err
+			b.report(t.TypeName(), reflect.TypeOf(resolver), err)
 		}
 	}
 
-	if err := b.finish(); err != nil {
-		return nil, err
+	b.finish()
+	if len(b.errs) > 0 {
+		return nil, b.errs
 	}
 
 	return &Schema{
@@ -99,13 +410,23 @@ func ApplyResolver(s *types.Schema, resolver interface{}) (*Schema, error) {
 		Query:        query,
 		Mutation:     mutation,
 		Subscription: subscription,
+		EnumNames:    b.enumNames,
+		ScalarTypes:  b.packerBuilder.ScalarTypes,
 	}, nil
 }
 
 type execBuilder struct {
-	schema        *types.Schema
-	resMap        map[typePair]*resMapEntry
-	packerBuilder *packer.Builder
+	schema         *types.Schema
+	resMap         map[typePair]*resMapEntry
+	packerBuilder  *packer.Builder
+	funcs          map[string]reflect.Value
+	methods        map[string]string
+	concreteTypes  map[string]reflect.Type
+	enumNames      map[string]map[int64]string
+	errs           errors.BindingErrors
+	argsCoords     map[*packer.StructPacker]string // coordinates of the fields whose arguments are packed
+	reportedInputs map[*packer.InputObjectError]bool
+	usedBy         []string // fields through which the resolver currently being bound is reached
 }
 
 type typePair struct {
@@ -120,20 +441,74 @@ type resMapEntry struct {
 
 func newBuilder(s *types.Schema) *execBuilder {
 	return &execBuilder{
-		schema:        s,
-		resMap:        make(map[typePair]*resMapEntry),
-		packerBuilder: packer.NewBuilder(),
+		schema:         s,
+		resMap:         make(map[typePair]*resMapEntry),
+		packerBuilder:  packer.NewBuilder(),
+		funcs:          make(map[string]reflect.Value),
+		methods:        make(map[string]string),
+		concreteTypes:  make(map[string]reflect.Type),
+		enumNames:      make(map[string]map[int64]string),
+		argsCoords:     make(map[*packer.StructPacker]string),
+		reportedInputs: make(map[*packer.InputObjectError]bool),
 	}
 }
 
-func (b *execBuilder) finish() error {
+func (b *execBuilder) finish() {
 	for _, entry := range b.resMap {
 		for _, target := range entry.targets {
 			*target = entry.exec
 		}
 	}
 
-	return b.packerBuilder.Finish()
+	for _, err := range b.packerBuilder.Finish() {
+		coord := err.InputType + "." + err.Name
+		if err.InputType == "" {
+			coord = fmt.Sprintf("%s(%s:)", b.argsCoords[err.Packer], err.Name)
+		}
+		b.report(coord, err.GoType, fmt.Errorf("invalid default value: %s", err.Err))
+	}
+}
+
+// reportFieldErrors reports the problems with the fields of the struct goType receiving the input
+// values with the given coordinate function. The problems of input objects are reported with the
+// coordinates of their input fields, once per input object.
+func (b *execBuilder) reportFieldErrors(errs packer.FieldErrors, goType reflect.Type, coordinate func(name string) string) {
+	for _, err := range errs {
+		inputErr, ok := err.Err.(*packer.InputObjectError)
+		if !ok {
+			b.report(coordinate(err.Name), goType, err.Err)
+			continue
+		}
+		if b.reportedInputs[inputErr] {
+			continue
+		}
+		b.reportedInputs[inputErr] = true
+		b.reportFieldErrors(inputErr.Errs, inputErr.GoType, func(name string) string {
+			return inputErr.Type + "." + name
+		})
+	}
+}
+
+// report records a problem binding the schema element with the given coordinate to goType.
+func (b *execBuilder) report(coordinate string, goType reflect.Type, err error) {
+	usedBy := make([]string, len(b.usedBy))
+	for i, u := range b.usedBy {
+		usedBy[len(usedBy)-1-i] = u
+	}
+	b.errs = append(b.errs, &errors.BindingError{
+		Coordinate: coordinate,
+		GoType:     goType,
+		Err:        err,
+		UsedBy:     usedBy,
+	})
+}
+
+// makeFieldExecUsedBy is makeFieldExec with usedBy added to the context of the reported problems.
+func (b *execBuilder) makeFieldExecUsedBy(usedBy string, typeName string, f *types.FieldDefinition, m reflect.Method, sf reflect.StructField,
+	methodIndex int, fieldIndex []int, fn reflect.Value, resolverType reflect.Type) *Field {
+	b.usedBy = append(b.usedBy, usedBy)
+	defer func() { b.usedBy = b.usedBy[:len(b.usedBy)-1] }()
+	return b.makeFieldExec(typeName, f, m, sf, methodIndex, fieldIndex, fn, resolverType)
 }
 
 func (b *execBuilder) assignExec(target *Resolvable, t types.Type, resolverType reflect.Type) error {
@@ -156,6 +531,18 @@ func (b *execBuilder) makeExec(t types.Type, resolverType reflect.Type) (Resolva
 	var nonNull bool
 	t, nonNull = unwrapNonNull(t)
 
+	// Maps and DynamicObjects resolve object types; bound to leaf or list types, they are checked
+	// like any other Go type. Only the values within dynamic objects are dynamic at any type.
+	if resolverType == dynamicValueType {
+		return b.makeDynamicExec(t)
+	}
+	if isDynamic(resolverType) {
+		switch t.(type) {
+		case *types.ObjectTypeDefinition, *types.InterfaceTypeDefinition, *types.Union:
+			return b.makeDynamicExec(t)
+		}
+	}
+
 	switch t := t.(type) {
 	case *types.ObjectTypeDefinition:
 		return b.makeObjectExec(t.Name, t.Fields, nil, nonNull, resolverType)
@@ -179,7 +566,7 @@ func (b *execBuilder) makeExec(t types.Type, resolverType reflect.Type) (Resolva
 		return makeScalarExec(t, resolverType)
 
 	case *types.EnumTypeDefinition:
-		return &Scalar{}, nil
+		return b.makeEnumExec(t, resolverType)
 
 	case *types.List:
 		if resolverType.Kind() != reflect.Slice {
@@ -196,6 +583,97 @@ func (b *execBuilder) makeExec(t types.Type, resolverType reflect.Type) (Resolva
 	}
 }
 
+// makeEnumExec checks that values of resolverType can be serialized as values of the enum: either
+// as strings, by the registered values of the enum, by methods MarshalEnum and UnmarshalEnum or by
+// a String method.
+func (b *execBuilder) makeEnumExec(t *types.EnumTypeDefinition, resolverType reflect.Type) (Resolvable, error) {
+	if packer.HasEnumMethods(resolverType) {
+		if err := packer.CheckEnumMethods(t, resolverType); err != nil {
+			return nil, err
+		}
+		return &Scalar{}, nil
+	}
+	if packer.IsInteger(resolverType) {
+		if _, ok := b.enumNames[t.Name]; !ok && !resolverType.Implements(stringerType) {
+			return nil, fmt.Errorf("can not use %s as enum %q: register the values of the enum or add methods MarshalEnum and UnmarshalEnum", resolverType, t.Name)
+		}
+	}
+	return &Scalar{}, nil
+}
+
+// makeDynamicExec makes the exec of a type whose values are only known at execution time.
+// Objects are resolved from maps or DynamicObjects and leaf values are serialized as they are.
+func (b *execBuilder) makeDynamicExec(t types.Type) (Resolvable, error) {
+	switch t := t.(type) {
+	case *types.ObjectTypeDefinition:
+		return b.makeDynamicObjectExec(t.Name, t.Fields, nil)
+
+	case *types.InterfaceTypeDefinition:
+		return b.makeDynamicObjectExec(t.Name, t.Fields, t.PossibleTypes)
+
+	case *types.Union:
+		return b.makeDynamicObjectExec(t.Name, nil, t.UnionMemberTypes)
+
+	case *types.List:
+		e := &List{}
+		if err := b.assignExec(&e.Elem, t.OfType, dynamicValueType); err != nil {
+			return nil, err
+		}
+		return e, nil
+
+	default:
+		return &Scalar{}, nil
+	}
+}
+
+func (b *execBuilder) makeDynamicObjectExec(typeName string, fields types.FieldsDefinition, possibleTypes []*types.ObjectTypeDefinition) (*Object, error) {
+	if sub, ok := b.schema.EntryPoints["subscription"]; ok && typeName == sub.TypeName() {
+		return nil, fmt.Errorf("subscription type %q can not be resolved by a dynamic object", typeName)
+	}
+
+	Fields := make(map[string]*Field)
+	for _, f := range fields {
+		fe := &Field{
+			FieldDefinition: *f,
+			TypeName:        typeName,
+			MethodIndex:     -1,
+			TraceLabel:      fmt.Sprintf("GraphQL field: %s.%s", typeName, f.Name),
+			Dynamic:         true,
+		}
+		coord := typeName + "." + f.Name
+		argsChecker, err := b.packerBuilder.MakeValuesChecker(f.Arguments)
+		if fieldErrs, ok := err.(packer.FieldErrors); ok {
+			b.reportFieldErrors(fieldErrs, dynamicValueType, func(name string) string {
+				return fmt.Sprintf("%s(%s:)", coord, name)
+			})
+		}
+		fe.ArgsChecker = argsChecker
+		if err := b.assignExec(&fe.ValueExec, f.Type, dynamicValueType); err != nil {
+			b.report(coord, dynamicValueType, err)
+		}
+		Fields[f.Name] = fe
+	}
+
+	typeAssertions := make(map[string]*TypeAssertion)
+	for _, impl := range possibleTypes {
+		a := &TypeAssertion{
+			MethodIndex: -1,
+			TypeName:    impl.Name,
+		}
+		if err := b.assignExec(&a.TypeExec, impl, dynamicValueType); err != nil {
+			b.report(typeName, dynamicValueType, err)
+			continue
+		}
+		typeAssertions[impl.Name] = a
+	}
+
+	return &Object{
+		Name:           typeName,
+		Fields:         Fields,
+		TypeAssertions: typeAssertions,
+	}, nil
+}
+
 func makeScalarExec(t *types.ScalarTypeDefinition, resolverType reflect.Type) (Resolvable, error) {
 	implementsType := false
 	switch r := reflect.New(resolverType).Interface().(type) {
@@ -209,6 +687,8 @@ func makeScalarExec(t *types.ScalarTypeDefinition, resolverType reflect.Type) (R
 		implementsType = t.Name == "Boolean"
 	case decode.Unmarshaler:
 		implementsType = r.ImplementsGraphQLType(t.Name)
+	case decode.Marshaler:
+		implementsType = r.ImplementsGraphQLType(t.Name)
 	}
 
 	if !implementsType {
@@ -225,63 +705,119 @@ func (b *execBuilder) makeObjectExec(typeName string, fields types.FieldsDefinit
 		}
 	}
 
-	methodHasReceiver := resolverType.Kind() != reflect.Interface
-
 	Fields := make(map[string]*Field)
 	rt := unwrapPtr(resolverType)
-	fieldsCount := fieldCount(rt, map[string]int{})
 	for _, f := range fields {
+		coord := typeName + "." + f.Name
+		if fn, ok := b.funcs[coord]; ok {
+			Fields[f.Name] = b.makeFieldExecUsedBy("function bound to "+coord, typeName, f, reflect.Method{}, reflect.StructField{}, -1, nil, fn, resolverType)
+			continue
+		}
+
 		var fieldIndex []int
 		methodIndex := findMethod(resolverType, f.Name)
+		if name, ok := b.methods[coord]; ok {
+			m, ok := resolverType.MethodByName(name)
+			if !ok {
+				b.report(coord, resolverType, fmt.Errorf("%s does not resolve %q: missing method %q bound to field %q", resolverType, typeName, name, f.Name))
+				continue
+			}
+			methodIndex = m.Index
+		}
 		if b.schema.UseFieldResolvers && methodIndex == -1 {
-			if fieldsCount[strings.ToLower(stripUnderscore(f.Name))] > 1 {
-				return nil, fmt.Errorf("%s does not resolve %q: ambiguous field %q", resolverType, typeName, f.Name)
+			var ambiguous bool
+			fieldIndex, ambiguous = packer.FindField(rt, f.Name, false)
+			if ambiguous {
+				b.report(coord, resolverType, fmt.Errorf("%s does not resolve %q: ambiguous field %q", resolverType, typeName, f.Name))
+				continue
 			}
-			fieldIndex = findField(rt, f.Name, []int{})
 		}
 		if methodIndex == -1 && len(fieldIndex) == 0 {
 			hint := ""
 			if findMethod(reflect.PtrTo(resolverType), f.Name) != -1 {
 				hint = " (hint: the method exists on the pointer type)"
 			}
-			return nil, fmt.Errorf("%s does not resolve %q: missing method for field %q%s", resolverType, typeName, f.Name, hint)
+			b.report(coord, resolverType, fmt.Errorf("%s does not resolve %q: missing method for field %q%s", resolverType, typeName, f.Name, hint))
+			continue
 		}
 
 		var m reflect.Method
 		var sf reflect.StructField
+		name := ""
 		if methodIndex != -1 {
 			m = resolverType.Method(methodIndex)
+			name = m.Name
 		} else {
 			sf = rt.FieldByIndex(fieldIndex)
+			name = sf.Name
 		}
-		fe, err := b.makeFieldExec(typeName, f, m, sf, methodIndex, fieldIndex, methodHasReceiver)
-		if err != nil {
-			return nil, fmt.Errorf("%s\n\tused by (%s).%s", err, resolverType, m.Name)
-		}
-		Fields[f.Name] = fe
+		usedBy := fmt.Sprintf("(%s).%s", resolverType, name)
+		Fields[f.Name] = b.makeFieldExecUsedBy(usedBy, typeName, f, m, sf, methodIndex, fieldIndex, reflect.Value{}, resolverType)
 	}
 
-	// Check type assertions when
-	//	1) using method resolvers
-	//	2) Or resolver is not an interface type
 	typeAssertions := make(map[string]*TypeAssertion)
-	if !b.schema.UseFieldResolvers || resolverType.Kind() != reflect.Interface {
-		for _, impl := range possibleTypes {
-			methodIndex := findMethod(resolverType, "To"+impl.Name)
-			if methodIndex == -1 {
-				return nil, fmt.Errorf("%s does not resolve %q: missing method %q to convert to %q", resolverType, typeName, "To"+impl.Name, impl.Name)
+	for _, impl := range possibleTypes {
+		methodIndex := findMethod(resolverType, "To"+impl.Name)
+		if goType, ok := b.concreteTypes[impl.Name]; ok && methodIndex == -1 && resolverType.Kind() == reflect.Interface {
+			if !goType.Implements(resolverType) {
+				b.report(typeName, resolverType, fmt.Errorf("%s does not resolve %q: %s mapped to %q does not implement it", resolverType, typeName, goType, impl.Name))
+				continue
 			}
-			if resolverType.Method(methodIndex).Type.NumOut() != 2 {
-				return nil, fmt.Errorf("%s does not resolve %q: method %q should return a value and a bool indicating success", resolverType, typeName, "To"+impl.Name)
+			a := &TypeAssertion{
+				MethodIndex: -1,
+				GoType:      goType,
+			}
+			if goType.Implements(typeNamerType) {
+				// The Go type may resolve several object types.
+				a.TypeName = impl.Name
 			}
+			if err := b.assignExec(&a.TypeExec, impl, goType); err != nil {
+				b.report(typeName, resolverType, err)
+				continue
+			}
+			typeAssertions[impl.Name] = a
+			continue
+		}
+		if methodIndex == -1 && resolverType.Kind() != reflect.Interface && resolverType.Implements(typeNamerType) {
+			// The resolver itself resolves each object type it reports.
 			a := &TypeAssertion{
-				MethodIndex: methodIndex,
+				MethodIndex: -1,
+				TypeName:    impl.Name,
 			}
-			if err := b.assignExec(&a.TypeExec, impl, resolverType.Method(methodIndex).Type.Out(0)); err != nil {
-				return nil, err
+			if err := b.assignExec(&a.TypeExec, impl, resolverType); err != nil {
+				b.report(typeName, resolverType, err)
+				continue
 			}
 			typeAssertions[impl.Name] = a
+			continue
+		}
+
+		// Check type assertions when
+		//	1) using method resolvers
+		//	2) Or resolver is not an interface type
+		if b.schema.UseFieldResolvers && resolverType.Kind() == reflect.Interface {
+			continue
 		}
+		if methodIndex == -1 {
+			hint := ""
+			if resolverType.Kind() == reflect.Interface {
+				hint = fmt.Sprintf(" (hint: alternatively, register the resolver type of %q)", impl.Name)
+			}
+			b.report(typeName, resolverType, fmt.Errorf("%s does not resolve %q: missing method %q to convert to %q%s", resolverType, typeName, "To"+impl.Name, impl.Name, hint))
+			continue
+		}
+		if resolverType.Method(methodIndex).Type.NumOut() != 2 {
+			b.report(typeName, resolverType, fmt.Errorf("%s does not resolve %q: method %q should return a value and a bool indicating success", resolverType, typeName, "To"+impl.Name))
+			continue
+		}
+		a := &TypeAssertion{
+			MethodIndex: methodIndex,
+		}
+		if err := b.assignExec(&a.TypeExec, impl, resolverType.Method(methodIndex).Type.Out(0)); err != nil {
+			b.report(typeName, resolverType, err)
+			continue
+		}
+		typeAssertions[impl.Name] = a
 	}
 
 	return &Object{
@@ -295,19 +831,28 @@ var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
 var errorType = reflect.TypeOf((*error)(nil)).Elem()
 
 func (b *execBuilder) makeFieldExec(typeName string, f *types.FieldDefinition, m reflect.Method, sf reflect.StructField,
-	methodIndex int, fieldIndex []int, methodHasReceiver bool) (*Field, error) {
+	methodIndex int, fieldIndex []int, fn reflect.Value, resolverType reflect.Type) *Field {
+	coord := typeName + "." + f.Name
 
 	var argsPacker *packer.StructPacker
 	var hasError bool
 	var hasContext bool
+	var hasSource bool
 
-	// Validate resolver method only when there is one
-	if methodIndex != -1 {
-		in := make([]reflect.Type, m.Type.NumIn())
+	var callType reflect.Type
+	if fn.IsValid() {
+		callType = fn.Type()
+	} else if methodIndex != -1 {
+		callType = m.Type
+	}
+
+	// Validate resolver method or function only when there is one
+	if callType != nil {
+		in := make([]reflect.Type, callType.NumIn())
 		for i := range in {
-			in[i] = m.Type.In(i)
+			in[i] = callType.In(i)
 		}
-		if methodHasReceiver {
+		if !fn.IsValid() && resolverType.Kind() != reflect.Interface {
 			in = in[1:] // first parameter is receiver
 		}
 
@@ -316,35 +861,57 @@ func (b *execBuilder) makeFieldExec(typeName string, f *types.FieldDefinition, m
 			in = in[1:]
 		}
 
+		if fn.IsValid() {
+			numArgs := 0
+			if len(f.Arguments) > 0 {
+				numArgs = 1
+			}
+			hasSource = len(in) > numArgs
+			if hasSource {
+				if !resolverType.AssignableTo(in[0]) {
+					b.report(coord, resolverType, fmt.Errorf("source parameter of type %s can not be used for %s", in[0], resolverType))
+				}
+				in = in[1:]
+			}
+		}
+
 		if len(f.Arguments) > 0 {
 			if len(in) == 0 {
-				return nil, fmt.Errorf("must have parameter for field arguments")
-			}
-			var err error
-			argsPacker, err = b.packerBuilder.MakeStructPacker(f.Arguments, in[0])
-			if err != nil {
-				return nil, err
+				b.report(coord, resolverType, fmt.Errorf("must have parameter for field arguments"))
+			} else {
+				var err error
+				argsPacker, err = b.packerBuilder.MakeStructPacker(f.Arguments, in[0])
+				if fieldErrs, ok := err.(packer.FieldErrors); ok {
+					b.reportFieldErrors(fieldErrs, in[0], func(name string) string {
+						return fmt.Sprintf("%s(%s:)", coord, name)
+					})
+				} else if err != nil {
+					b.report(coord, in[0], err)
+				} else {
+					b.argsCoords[argsPacker] = coord
+				}
+				in = in[1:]
 			}
-			in = in[1:]
 		}
 
 		if len(in) > 0 {
-			return nil, fmt.Errorf("too many parameters")
+			b.report(coord, resolverType, fmt.Errorf("too many parameters"))
 		}
 
 		maxNumOfReturns := 2
-		if m.Type.NumOut() < maxNumOfReturns-1 {
-			return nil, fmt.Errorf("too few return values")
+		if callType.NumOut() < maxNumOfReturns-1 {
+			b.report(coord, resolverType, fmt.Errorf("too few return values"))
+			return nil
 		}
 
-		if m.Type.NumOut() > maxNumOfReturns {
-			return nil, fmt.Errorf("too many return values")
+		if callType.NumOut() > maxNumOfReturns {
+			b.report(coord, resolverType, fmt.Errorf("too many return values"))
 		}
 
-		hasError = m.Type.NumOut() == maxNumOfReturns
+		hasError = callType.NumOut() == maxNumOfReturns
 		if hasError {
-			if m.Type.Out(maxNumOfReturns-1) != errorType {
-				return nil, fmt.Errorf(`must have "error" as its last return value`)
+			if callType.Out(maxNumOfReturns-1) != errorType {
+				b.report(coord, resolverType, fmt.Errorf(`must have "error" as its last return value`))
 			}
 		}
 	}
@@ -358,23 +925,25 @@ func (b *execBuilder) makeFieldExec(typeName string, f *types.FieldDefinition, m
 		ArgsPacker:      argsPacker,
 		HasError:        hasError,
 		TraceLabel:      fmt.Sprintf("GraphQL field: %s.%s", typeName, f.Name),
+		Func:            fn,
+		HasSource:       hasSource,
 	}
 
 	var out reflect.Type
-	if methodIndex != -1 {
-		out = m.Type.Out(0)
+	if callType != nil {
+		out = callType.Out(0)
 		sub, ok := b.schema.EntryPoints["subscription"]
 		if ok && typeName == sub.TypeName() && out.Kind() == reflect.Chan {
-			out = m.Type.Out(0).Elem()
+			out = callType.Out(0).Elem()
 		}
 	} else {
 		out = sf.Type
 	}
 	if err := b.assignExec(&fe.ValueExec, f.Type, out); err != nil {
-		return nil, err
+		b.report(coord, resolverType, err)
 	}
 
-	return fe, nil
+	return fe
 }
 
 func findMethod(t reflect.Type, name string) int {
@@ -386,48 +955,6 @@ func findMethod(t reflect.Type, name string) int {
 	return -1
 }
 
-func findField(t reflect.Type, name string, index []int) []int {
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-
-		if field.Type.Kind() == reflect.Struct && field.Anonymous {
-			newIndex := findField(field.Type, name, []int{i})
-			if len(newIndex) > 1 {
-				return append(index, newIndex...)
-			}
-		}
-
-		if strings.EqualFold(stripUnderscore(name), stripUnderscore(field.Name)) {
-			return append(index, i)
-		}
-	}
-
-	return index
-}
-
-// fieldCount helps resolve ambiguity when more than one embedded struct contains fields with the same name.
-func fieldCount(t reflect.Type, count map[string]int) map[string]int {
-	if t.Kind() != reflect.Struct {
-		return nil
-	}
-
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		fieldName := strings.ToLower(stripUnderscore(field.Name))
-
-		if field.Type.Kind() == reflect.Struct && field.Anonymous {
-			count = fieldCount(field.Type, count)
-		} else {
-			if _, ok := count[fieldName]; !ok {
-				count[fieldName] = 0
-			}
-			count[fieldName]++
-		}
-	}
-
-	return count
-}
-
 func unwrapNonNull(t types.Type) (types.Type, bool) {
 	if nn, ok := t.(*types.NonNull); ok {
 		return nn.OfType, true
@@ -1,6 +1,7 @@
 package selected
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sync"
@@ -48,6 +49,7 @@ type Selection interface {
 type SchemaField struct {
 	resolvable.Field
 	Alias       string
+	Loc         errors.Location // location of the field in the document
 	Args        map[string]interface{}
 	PackedArgs  reflect.Value
 	Sels        []Selection
@@ -92,6 +94,7 @@ func applySelectionSet(r *Request, s *resolvable.Schema, e *resolvable.Object, s
 					flattenedSels = append(flattenedSels, &SchemaField{
 						Field:       s.Meta.FieldSchema,
 						Alias:       field.Alias.Name,
+						Loc:         field.Alias.Loc,
 						Sels:        applySelectionSet(r, s, s.Meta.Schema, field.SelectionSet),
 						Async:       true,
 						FixedResult: reflect.ValueOf(introspection.WrapSchema(r.Schema)),
@@ -107,17 +110,19 @@ func applySelectionSet(r *Request, s *resolvable.Schema, e *resolvable.Object, s
 						return nil
 					}
 
-					t, ok := r.Schema.Types[v.String()]
-					if !ok {
-						return nil
+					// An unknown type resolves to null.
+					result := reflect.ValueOf((*introspection.Type)(nil))
+					if t, ok := r.Schema.Types[v.String()]; ok {
+						result = reflect.ValueOf(introspection.WrapType(t))
 					}
 
 					flattenedSels = append(flattenedSels, &SchemaField{
 						Field:       s.Meta.FieldType,
 						Alias:       field.Alias.Name,
+						Loc:         field.Alias.Loc,
 						Sels:        applySelectionSet(r, s, s.Meta.Type, field.SelectionSet),
 						Async:       true,
-						FixedResult: reflect.ValueOf(introspection.WrapType(t)),
+						FixedResult: result,
 					})
 				}
 
@@ -126,15 +131,39 @@ func applySelectionSet(r *Request, s *resolvable.Schema, e *resolvable.Object, s
 
 				var args map[string]interface{}
 				var packedArgs reflect.Value
+				if fe.Dynamic {
+					args = make(map[string]interface{})
+					for _, v := range fe.Arguments {
+						if v.Default != nil {
+							args[v.Name.Name] = v.Default.Deserialize(nil)
+						}
+					}
+					for _, arg := range field.Arguments {
+						if packer.IsGiven(arg.Value, r.Vars) {
+							args[arg.Name.Name] = arg.Value.Deserialize(r.Vars)
+						}
+					}
+					if fe.ArgsChecker != nil {
+						if err := fe.ArgsChecker.Check(args); err != nil {
+							r.AddError(argumentError(field, r.Vars, err))
+							return
+						}
+					}
+				}
 				if fe.ArgsPacker != nil {
 					args = make(map[string]interface{})
+					literals := make(map[string]interface{})
 					for _, arg := range field.Arguments {
+						if !packer.IsGiven(arg.Value, r.Vars) {
+							continue
+						}
 						args[arg.Name.Name] = arg.Value.Deserialize(r.Vars)
+						literals[arg.Name.Name] = packer.DeserializeLiteral(arg.Value, r.Vars)
 					}
 					var err error
-					packedArgs, err = fe.ArgsPacker.Pack(args)
+					packedArgs, err = fe.ArgsPacker.Pack(literals)
 					if err != nil {
-						r.AddError(errors.Errorf("%s", err))
+						r.AddError(argumentError(field, r.Vars, err))
 						return
 					}
 				}
@@ -143,10 +172,11 @@ func applySelectionSet(r *Request, s *resolvable.Schema, e *resolvable.Object, s
 				flattenedSels = append(flattenedSels, &SchemaField{
 					Field:      *fe,
 					Alias:      field.Alias.Name,
+					Loc:        field.Alias.Loc,
 					Args:       args,
 					PackedArgs: packedArgs,
 					Sels:       fieldSels,
-					Async:      fe.HasContext || fe.ArgsPacker != nil || fe.HasError || HasAsyncSel(fieldSels),
+					Async:      fe.HasContext || fe.ArgsPacker != nil || fe.HasError || fe.Dynamic || HasAsyncSel(fieldSels),
 				})
 			}
 
@@ -171,6 +201,93 @@ func applySelectionSet(r *Request, s *resolvable.Schema, e *resolvable.Object, s
 	return
 }
 
+// argumentError converts an error packing the arguments of field. A violated @constraint is
+// reported with the path of the invalid value and the code CONSTRAINT_VIOLATION. A value of a
+// variable which can not be packed is reported with its path within the variable.
+func argumentError(field *types.Field, vars map[string]interface{}, err error) *errors.QueryError {
+	if ve, ok := err.(*packer.ValueError); ok {
+		if qErr := variableError(field, vars, ve); qErr != nil {
+			return qErr
+		}
+	}
+	qErr := errors.Errorf("%s", err)
+	ce, ok := err.(*packer.ConstraintError)
+	if !ok {
+		return qErr
+	}
+	if v, ok := field.Arguments.Get(ce.Path[0].(string)); ok {
+		qErr.Locations = []errors.Location{v.Location()}
+	}
+	qErr.Extensions = map[string]interface{}{
+		"code":     "CONSTRAINT_VIOLATION",
+		"argument": ce.PathString(),
+	}
+	return qErr
+}
+
+// variableError follows the path of the invalid value ve through the arguments of field. If it
+// leads into a variable, the error names the variable and the path of the value within it, like
+// the errors found while coercing the variables. Otherwise it returns nil.
+func variableError(field *types.Field, vars map[string]interface{}, ve *packer.ValueError) *errors.QueryError {
+	arg, ok := field.Arguments.Get(ve.Path[0].(string))
+	if !ok {
+		return nil
+	}
+	var value types.Value = arg
+	for i := 1; ; i++ {
+		if v, ok := value.(*types.Variable); ok {
+			return invalidVariableValue(v, vars[v.Name], ve.Path[i:], ve.Err)
+		}
+		if i == len(ve.Path) {
+			return nil
+		}
+		switch l := value.(type) {
+		case *types.ListValue:
+			value = l.Values[ve.Path[i].(int)]
+		case *types.ObjectValue:
+			value = nil
+			for _, f := range l.Fields {
+				if f.Name.Name == ve.Path[i] {
+					value = f.Value
+				}
+			}
+			if value == nil {
+				return nil
+			}
+		default:
+			// a single value given for a list
+			if _, ok := ve.Path[i].(int); !ok {
+				return nil
+			}
+		}
+	}
+}
+
+func invalidVariableValue(v *types.Variable, value interface{}, path []interface{}, err error) *errors.QueryError {
+	for _, p := range path {
+		switch p := p.(type) {
+		case int:
+			if list, ok := value.([]interface{}); ok {
+				value = list[p]
+			}
+		case string:
+			fields, _ := value.(map[string]interface{})
+			value = fields[p]
+		}
+	}
+	at := ""
+	if len(path) > 0 {
+		at = fmt.Sprintf(" at %q", packer.FormatPath(append([]interface{}{v.Name}, path...)))
+	}
+	b, jsonErr := json.Marshal(value)
+	if jsonErr != nil {
+		b = []byte(fmt.Sprintf("%v", value))
+	}
+	qErr := errors.Errorf("Variable %q got invalid value %s%s; %s", "$"+v.Name, b, at, err)
+	qErr.Locations = []errors.Location{v.Loc}
+	return qErr
+}
+
 func applyFragment(r *Request, s *resolvable.Schema, e *resolvable.Object, frag *types.Fragment) []Selection {
 	if frag.On.Name != e.Name {
 		t := r.Schema.Resolve(frag.On.Name)
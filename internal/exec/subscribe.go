@@ -15,11 +15,13 @@ import (
 )
 
 type Response struct {
-	Data   json.RawMessage
-	Errors []*errors.QueryError
+	Data     json.RawMessage
+	Errors   []*errors.QueryError
+	Warnings []*errors.QueryError
 }
 
 func (r *Request) Subscribe(ctx context.Context, s *resolvable.Schema, op *types.OperationDefinition) <-chan *Response {
+	r.op = op
 	var result reflect.Value
 	var f *fieldToExec
 	var err *errors.QueryError
@@ -39,12 +41,12 @@ func (r *Request) Subscribe(ctx context.Context, s *resolvable.Schema, op *types
 
 		var in []reflect.Value
 		if f.field.HasContext {
-			in = append(in, reflect.ValueOf(ctx))
+			in = append(in, reflect.ValueOf(r.withField(ctx, f, &pathSegment{nil, f.field.Alias})))
 		}
 		if f.field.ArgsPacker != nil {
 			in = append(in, f.field.PackedArgs)
 		}
-		callOut := f.resolver.Method(f.field.MethodIndex).Call(in)
+		callOut := f.field.Call(f.resolver, in)
 		result = callOut[0]
 
 		if f.field.HasError && !callOut[1].IsNil() {
@@ -54,6 +56,9 @@ func (r *Request) Subscribe(ctx context.Context, s *resolvable.Schema, op *types
 			case error:
 				err = errors.Errorf("%s", resolverErr)
 				err.ResolverError = resolverErr
+				err.Extensions = extensions(resolverErr)
+				err = r.internalError(ctx, err, resolverErr)
+				err.Locations = f.locs
 			default:
 				panic(fmt.Errorf("can only deal with *QueryError and error types, got %T", resolverErr))
 			}
@@ -71,9 +76,9 @@ func (r *Request) Subscribe(ctx context.Context, s *resolvable.Schema, op *types
 
 	if err != nil {
 		if _, nonNullChild := f.field.Type.(*types.NonNull); nonNullChild {
-			return sendAndReturnClosed(&Response{Errors: []*errors.QueryError{err}})
+			return sendAndReturnClosed(&Response{Errors: []*errors.QueryError{err}, Warnings: r.Warnings})
 		}
-		return sendAndReturnClosed(&Response{Data: []byte(fmt.Sprintf(`{"%s":null}`, f.field.Alias)), Errors: []*errors.QueryError{err}})
+		return sendAndReturnClosed(&Response{Data: []byte(fmt.Sprintf(`{"%s":null}`, f.field.Alias)), Errors: []*errors.QueryError{err}, Warnings: r.Warnings})
 	}
 
 	if ctxErr := ctx.Err(); ctxErr != nil {
@@ -87,6 +92,9 @@ func (r *Request) Subscribe(ctx context.Context, s *resolvable.Schema, op *types
 		return c
 	}
 
+	// the warnings added with the context of the subscription resolver, also while it produces
+	// events, are sent with the next event
+	sentWarnings := 0
 	go func() {
 		for {
 			// Check subscription context
@@ -119,9 +127,10 @@ func (r *Request) Subscribe(ctx context.Context, s *resolvable.Schema, op *types
 						Vars:   r.Request.Vars,
 						Schema: r.Request.Schema,
 					},
-					Limiter: r.Limiter,
-					Tracer:  r.Tracer,
-					Logger:  r.Logger,
+					Limiter:       r.Limiter,
+					Tracer:        r.Tracer,
+					Logger:        r.Logger,
+					InternalError: r.InternalError,
 				}
 				var out bytes.Buffer
 				func() {
@@ -138,7 +147,7 @@ func (r *Request) Subscribe(ctx context.Context, s *resolvable.Schema, op *types
 						defer subR.handlePanic(subCtx)
 
 						var buf bytes.Buffer
-						subR.execSelectionSet(subCtx, f.sels, f.field.Type, &pathSegment{nil, f.field.Alias}, s, resp, &buf)
+						subR.execSelectionSet(subCtx, f.sels, f.field.Type, &pathSegment{nil, f.field.Alias}, f.locs, s, resp, &buf)
 
 						propagateChildError := false
 						if _, nonNullChild := f.field.Type.(*types.NonNull); nonNullChild && resolvedToNull(&buf) {
@@ -159,9 +168,14 @@ func (r *Request) Subscribe(ctx context.Context, s *resolvable.Schema, op *types
 
 					// Send response within timeout
 					// TODO: maybe block until sent?
+					r.Mu.Lock()
+					n := len(r.Warnings)
+					warnings := append(r.Warnings[sentWarnings:n:n], subR.Warnings...)
+					r.Mu.Unlock()
 					select {
 					case <-subCtx.Done():
-					case c <- &Response{Data: out.Bytes(), Errors: subR.Errs}:
+					case c <- &Response{Data: out.Bytes(), Errors: subR.Errs, Warnings: warnings}:
+						sentWarnings = n
 					}
 				}()
 			}
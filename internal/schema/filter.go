@@ -0,0 +1,295 @@
+package schema
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// isBuiltin reports whether the type is part of every schema and thus never hidden.
+func isBuiltin(name string) bool {
+	switch name {
+	case "Int", "Float", "String", "Boolean", "ID":
+		return true
+	}
+	return strings.HasPrefix(name, "__")
+}
+
+// Hidden returns the schema coordinates of the types, fields, arguments, input fields and enum
+// values of s for which visible returns false, in a deterministic order. Elements of hidden types
+// are not visited. Built-in types and the root operation types are always visible.
+func Hidden(s *types.Schema, visible func(coordinate string, directives types.DirectiveList) bool) []string {
+	names := make([]string, 0, len(s.Types))
+	for name := range s.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	roots := make(map[string]bool)
+	for _, t := range s.EntryPoints {
+		roots[t.TypeName()] = true
+	}
+
+	var hidden []string
+	check := func(coordinate string, directives types.DirectiveList) bool {
+		if !visible(coordinate, directives) {
+			hidden = append(hidden, coordinate)
+			return false
+		}
+		return true
+	}
+	checkValues := func(prefix, suffix string, values types.ArgumentsDefinition) {
+		for _, v := range values {
+			check(prefix+v.Name.Name+suffix, v.Directives)
+		}
+	}
+	checkFields := func(typeName string, fields types.FieldsDefinition) {
+		for _, f := range fields {
+			coord := typeName + "." + f.Name
+			if check(coord, f.Directives) {
+				checkValues(coord+"(", ":)", f.Arguments)
+			}
+		}
+	}
+
+	for _, name := range names {
+		if isBuiltin(name) {
+			continue
+		}
+		t := s.Types[name]
+		var directives types.DirectiveList
+		switch t := t.(type) {
+		case *types.ObjectTypeDefinition:
+			directives = t.Directives
+		case *types.InterfaceTypeDefinition:
+			directives = t.Directives
+		case *types.Union:
+			directives = t.Directives
+		case *types.EnumTypeDefinition:
+			directives = t.Directives
+		case *types.InputObject:
+			directives = t.Directives
+		case *types.ScalarTypeDefinition:
+			directives = t.Directives
+		}
+		if !roots[name] && !check(name, directives) {
+			continue
+		}
+
+		switch t := t.(type) {
+		case *types.ObjectTypeDefinition:
+			checkFields(name, t.Fields)
+		case *types.InterfaceTypeDefinition:
+			checkFields(name, t.Fields)
+		case *types.EnumTypeDefinition:
+			for _, v := range t.EnumValuesDefinition {
+				check(name+"."+v.EnumValue, v.Directives)
+			}
+		case *types.InputObject:
+			checkValues(name+".", "", t.Values)
+		}
+	}
+	return hidden
+}
+
+// Filter returns a copy of s without the elements with the given schema coordinates, as
+// returned by Hidden. Fields, arguments and input fields whose type is hidden are removed as
+// well. Since a value can not be given for a removed argument or input field, removing a required
+// one, i.e. one of a non-null type without a default value, removes the field or input type it
+// belongs to. The definitions of s are not modified.
+func Filter(s *types.Schema, hidden []string) *types.Schema {
+	f := &filter{
+		hidden: make(map[string]bool, len(hidden)),
+		types:  make(map[string]types.NamedType, len(s.Types)),
+	}
+	for _, coord := range hidden {
+		f.hidden[coord] = true
+	}
+
+	for name, t := range s.Types {
+		if f.hidden[name] {
+			continue
+		}
+		if isBuiltin(name) {
+			f.types[name] = t
+			continue
+		}
+		switch t := t.(type) {
+		case *types.ObjectTypeDefinition:
+			c := *t
+			f.types[name] = &c
+		case *types.InterfaceTypeDefinition:
+			c := *t
+			f.types[name] = &c
+		case *types.Union:
+			c := *t
+			f.types[name] = &c
+		case *types.EnumTypeDefinition:
+			c := *t
+			f.types[name] = &c
+		case *types.InputObject:
+			c := *t
+			f.types[name] = &c
+		default:
+			f.types[name] = t
+		}
+	}
+
+	// Removing an input type may remove a required input field of another input type.
+	for removed := true; removed; {
+		removed = false
+		for name, t := range f.types {
+			if t, ok := t.(*types.InputObject); ok {
+				if _, ok := f.values(name+".", "", t.Values); !ok {
+					delete(f.types, name)
+					removed = true
+				}
+			}
+		}
+	}
+
+	for name, t := range f.types {
+		if isBuiltin(name) {
+			continue
+		}
+		switch t := t.(type) {
+		case *types.ObjectTypeDefinition:
+			t.Fields = f.fields(name, t.Fields)
+			interfaces := t.Interfaces
+			t.Interfaces, t.InterfaceNames = nil, nil
+			for _, intf := range interfaces {
+				if c, ok := f.types[intf.Name].(*types.InterfaceTypeDefinition); ok {
+					t.Interfaces = append(t.Interfaces, c)
+					t.InterfaceNames = append(t.InterfaceNames, c.Name)
+				}
+			}
+		case *types.InterfaceTypeDefinition:
+			t.Fields = f.fields(name, t.Fields)
+			t.PossibleTypes = f.objects(t.PossibleTypes)
+		case *types.Union:
+			t.UnionMemberTypes = f.objects(t.UnionMemberTypes)
+			t.TypeNames = make([]string, len(t.UnionMemberTypes))
+			for i, obj := range t.UnionMemberTypes {
+				t.TypeNames[i] = obj.Name
+			}
+		case *types.EnumTypeDefinition:
+			values := t.EnumValuesDefinition
+			t.EnumValuesDefinition = nil
+			for _, v := range values {
+				if !f.hidden[name+"."+v.EnumValue] {
+					t.EnumValuesDefinition = append(t.EnumValuesDefinition, v)
+				}
+			}
+		case *types.InputObject:
+			t.Values, _ = f.values(name+".", "", t.Values)
+		}
+	}
+
+	c := &types.Schema{
+		EntryPoints:       make(map[string]types.NamedType, len(s.EntryPoints)),
+		Types:             f.types,
+		Directives:        s.Directives,
+		UseFieldResolvers: s.UseFieldResolvers,
+		EntryPointNames:   s.EntryPointNames,
+		Objects:           f.objects(s.Objects),
+	}
+	for op, t := range s.EntryPoints {
+		c.EntryPoints[op] = f.types[t.TypeName()]
+	}
+	for _, u := range s.Unions {
+		if t, ok := f.types[u.Name].(*types.Union); ok {
+			c.Unions = append(c.Unions, t)
+		}
+	}
+	for _, e := range s.Enums {
+		if t, ok := f.types[e.Name].(*types.EnumTypeDefinition); ok {
+			c.Enums = append(c.Enums, t)
+		}
+	}
+	return c
+}
+
+type filter struct {
+	hidden map[string]bool
+	types  map[string]types.NamedType
+}
+
+// typ maps t to the copy of its named type, or returns nil if the named type is hidden.
+func (f *filter) typ(t types.Type) types.Type {
+	switch t := t.(type) {
+	case *types.NonNull:
+		ofType := f.typ(t.OfType)
+		if ofType == nil {
+			return nil
+		}
+		return &types.NonNull{OfType: ofType}
+	case *types.List:
+		ofType := f.typ(t.OfType)
+		if ofType == nil {
+			return nil
+		}
+		return &types.List{OfType: ofType}
+	case types.NamedType:
+		if c, ok := f.types[t.TypeName()]; ok {
+			return c
+		}
+		return nil
+	default:
+		return t
+	}
+}
+
+func (f *filter) fields(typeName string, fields types.FieldsDefinition) types.FieldsDefinition {
+	var l types.FieldsDefinition
+	for _, field := range fields {
+		coord := typeName + "." + field.Name
+		if f.hidden[coord] {
+			continue
+		}
+		t := f.typ(field.Type)
+		if t == nil {
+			continue
+		}
+		args, ok := f.values(coord+"(", ":)", field.Arguments)
+		if !ok {
+			continue
+		}
+		c := *field
+		c.Type = t
+		c.Arguments = args
+		l = append(l, &c)
+	}
+	return l
+}
+
+// values returns the visible values and whether all required values are visible.
+func (f *filter) values(prefix, suffix string, values types.ArgumentsDefinition) (types.ArgumentsDefinition, bool) {
+	var l types.ArgumentsDefinition
+	for _, v := range values {
+		var t types.Type
+		if !f.hidden[prefix+v.Name.Name+suffix] {
+			t = f.typ(v.Type)
+		}
+		if t == nil {
+			if _, ok := v.Type.(*types.NonNull); ok && v.Default == nil {
+				return nil, false
+			}
+			continue
+		}
+		c := *v
+		c.Type = t
+		l = append(l, &c)
+	}
+	return l, true
+}
+
+func (f *filter) objects(objects []*types.ObjectTypeDefinition) []*types.ObjectTypeDefinition {
+	var l []*types.ObjectTypeDefinition
+	for _, obj := range objects {
+		if c, ok := f.types[obj.Name].(*types.ObjectTypeDefinition); ok {
+			l = append(l, c)
+		}
+	}
+	return l
+}
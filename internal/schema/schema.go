@@ -2,6 +2,7 @@ package schema
 
 import (
 	"fmt"
+	"strings"
 	"text/scanner"
 
 	"github.com/graph-gophers/graphql-go/errors"
@@ -26,13 +27,118 @@ func New() *types.Schema {
 	return s
 }
 
+// Source is a named GraphQL schema document.
+type Source struct {
+	Name string
+	Body string
+}
+
 func Parse(s *types.Schema, schemaString string, useStringDescriptions bool) error {
-	l := common.NewLexer(schemaString, useStringDescriptions)
-	err := l.CatchSyntaxError(func() { parseSchema(s, l) })
-	if err != nil {
+	return ParseSources(s, []Source{{Body: schemaString}}, useStringDescriptions)
+}
+
+// ParseSources parses several schema documents into s as if they were a single document. Types,
+// directives and extensions may be declared in any source and are merged once all sources
+// have been read. Locations reported in errors carry the name of the source they refer to.
+func ParseSources(s *types.Schema, sources []Source, useStringDescriptions bool) error {
+	if err := Declare(s, sources, useStringDescriptions); err != nil {
 		return err
 	}
+	return Resolve(s)
+}
+
+// Declare parses several schema documents into s without resolving them, so that definitions
+// may be added or inspected before Resolve is called.
+func Declare(s *types.Schema, sources []Source, useStringDescriptions bool) error {
+	for _, src := range sources {
+		l := common.NewSourceLexer(src.Name, src.Body, useStringDescriptions)
+		if err := catchDefinitionError(func() *errors.QueryError {
+			return l.CatchSyntaxError(func() { parseSchema(s, l) })
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// definitionError is raised by parseSchema for a definition which is valid syntax but conflicts
+// with an earlier definition, possibly from another source.
+type definitionError struct {
+	err *errors.QueryError
+}
+
+func catchDefinitionError(f func() *errors.QueryError) (err *errors.QueryError) {
+	defer func() {
+		if v := recover(); v != nil {
+			de, ok := v.(definitionError)
+			if !ok {
+				panic(v)
+			}
+			err = de.err
+		}
+	}()
+	return f()
+}
+
+// declareType adds t to the types of s. A type which is already defined by a schema document is
+// reported with the locations of both definitions; built-in types may be redefined.
+func declareType(s *types.Schema, t types.NamedType) {
+	if prev, ok := s.Types[t.TypeName()]; ok && !isBuiltinType(t.TypeName()) {
+		err := errors.Errorf("type %q is defined more than once", t.TypeName())
+		err.Locations = []errors.Location{typeLoc(prev), typeLoc(t)}
+		panic(definitionError{err})
+	}
+	s.Types[t.TypeName()] = t
+}
+
+func isBuiltinType(name string) bool {
+	switch name {
+	case "Int", "Float", "String", "Boolean", "ID":
+		return true
+	}
+	return strings.HasPrefix(name, "__")
+}
+
+// typeLoc returns the location of the definition of t.
+func typeLoc(t types.NamedType) errors.Location {
+	switch t := t.(type) {
+	case *types.ScalarTypeDefinition:
+		return t.Loc
+	case *types.ObjectTypeDefinition:
+		return t.Loc
+	case *types.InterfaceTypeDefinition:
+		return t.Loc
+	case *types.Union:
+		return t.Loc
+	case *types.EnumTypeDefinition:
+		return t.Loc
+	case *types.InputObject:
+		return t.Loc
+	}
+	return errors.Location{}
+}
+
+// declareEntryPoint reads the root operation type for the operation kind name.
+func declareEntryPoint(s *types.Schema, name string, l *common.Lexer) {
+	loc := l.Location()
+	s.EntryPointNames[name] = l.ConsumeIdent()
+	if s.EntryPointLocs == nil {
+		s.EntryPointLocs = make(map[string]errors.Location)
+	}
+	s.EntryPointLocs[name] = loc
+}
+
+// locatedErrorf returns an error at the given location.
+func locatedErrorf(loc errors.Location, format string, a ...interface{}) *errors.QueryError {
+	err := errors.Errorf(format, a...)
+	err.Locations = []errors.Location{loc}
+	return err
+}
 
+// Resolve links the definitions in s after they have been declared: it merges type extensions,
+// resolves type references, determines the root operation types and validates the use of
+// directives. It must be called exactly once on a schema.
+func Resolve(s *types.Schema) error {
 	if err := mergeExtensions(s); err != nil {
 		return err
 	}
@@ -71,7 +177,7 @@ func Parse(s *types.Schema, schemaString string, useStringDescriptions bool) err
 	for key, name := range s.EntryPointNames {
 		t, ok := s.Types[name]
 		if !ok {
-			return errors.Errorf("type %q not found", name)
+			return locatedErrorf(s.EntryPointLocs[key], "type %q not found", name)
 		}
 		s.EntryPoints[key] = t
 	}
@@ -89,15 +195,15 @@ func Parse(s *types.Schema, schemaString string, useStringDescriptions bool) err
 		for i, intfName := range obj.InterfaceNames {
 			t, ok := s.Types[intfName]
 			if !ok {
-				return errors.Errorf("interface %q not found", intfName)
+				return locatedErrorf(obj.Loc, "interface %q not found", intfName)
 			}
 			intf, ok := t.(*types.InterfaceTypeDefinition)
 			if !ok {
-				return errors.Errorf("type %q is not an interface", intfName)
+				return locatedErrorf(obj.Loc, "type %q is not an interface", intfName)
 			}
 			for _, f := range intf.Fields.Names() {
 				if obj.Fields.Get(f) == nil {
-					return errors.Errorf("interface %q expects field %q but %q does not provide it", intfName, f, obj.Name)
+					return locatedErrorf(obj.Loc, "interface %q expects field %q but %q does not provide it", intfName, f, obj.Name)
 				}
 			}
 			obj.Interfaces[i] = intf
@@ -113,11 +219,11 @@ func Parse(s *types.Schema, schemaString string, useStringDescriptions bool) err
 		for i, name := range union.TypeNames {
 			t, ok := s.Types[name]
 			if !ok {
-				return errors.Errorf("object type %q not found", name)
+				return locatedErrorf(union.Loc, "object type %q not found", name)
 			}
 			obj, ok := t.(*types.ObjectTypeDefinition)
 			if !ok {
-				return errors.Errorf("type %q is not an object", name)
+				return locatedErrorf(union.Loc, "type %q is not an object", name)
 			}
 			union.UnionMemberTypes[i] = obj
 		}
@@ -147,11 +253,11 @@ func mergeExtensions(s *types.Schema) error {
 	for _, ext := range s.Extensions {
 		typ := s.Types[ext.Type.TypeName()]
 		if typ == nil {
-			return fmt.Errorf("trying to extend unknown type %q", ext.Type.TypeName())
+			return locatedErrorf(typeLoc(ext.Type), "trying to extend unknown type %q", ext.Type.TypeName())
 		}
 
 		if typ.Kind() != ext.Type.Kind() {
-			return fmt.Errorf("trying to extend type %q with type %q", typ.Kind(), ext.Type.Kind())
+			return locatedErrorf(typeLoc(ext.Type), "trying to extend type %q with type %q", typ.Kind(), ext.Type.Kind())
 		}
 
 		switch og := typ.(type) {
@@ -160,7 +266,7 @@ func mergeExtensions(s *types.Schema) error {
 
 			for _, field := range e.Fields {
 				if og.Fields.Get(field.Name) != nil {
-					return fmt.Errorf("extended field %q already exists", field.Name)
+					return locatedErrorf(field.Loc, "extended field %q already exists", field.Name)
 				}
 			}
 			og.Fields = append(og.Fields, e.Fields...)
@@ -168,31 +274,34 @@ func mergeExtensions(s *types.Schema) error {
 			for _, en := range e.InterfaceNames {
 				for _, on := range og.InterfaceNames {
 					if on == en {
-						return fmt.Errorf("interface %q implemented in the extension is already implemented in %q", on, og.Name)
+						return locatedErrorf(e.Loc, "interface %q implemented in the extension is already implemented in %q", on, og.Name)
 					}
 				}
 			}
 			og.InterfaceNames = append(og.InterfaceNames, e.InterfaceNames...)
+			og.Directives = append(og.Directives, e.Directives...)
 
 		case *types.InputObject:
 			e := ext.Type.(*types.InputObject)
 
 			for _, field := range e.Values {
 				if og.Values.Get(field.Name.Name) != nil {
-					return fmt.Errorf("extended field %q already exists", field.Name)
+					return locatedErrorf(field.Loc, "extended field %q already exists", field.Name.Name)
 				}
 			}
 			og.Values = append(og.Values, e.Values...)
+			og.Directives = append(og.Directives, e.Directives...)
 
 		case *types.InterfaceTypeDefinition:
 			e := ext.Type.(*types.InterfaceTypeDefinition)
 
 			for _, field := range e.Fields {
 				if og.Fields.Get(field.Name) != nil {
-					return fmt.Errorf("extended field %s already exists", field.Name)
+					return locatedErrorf(field.Loc, "extended field %s already exists", field.Name)
 				}
 			}
 			og.Fields = append(og.Fields, e.Fields...)
+			og.Directives = append(og.Directives, e.Directives...)
 
 		case *types.Union:
 			e := ext.Type.(*types.Union)
@@ -200,11 +309,12 @@ func mergeExtensions(s *types.Schema) error {
 			for _, en := range e.TypeNames {
 				for _, on := range og.TypeNames {
 					if on == en {
-						return fmt.Errorf("union type %q already declared in %q", on, og.Name)
+						return locatedErrorf(e.Loc, "union type %q already declared in %q", on, og.Name)
 					}
 				}
 			}
 			og.TypeNames = append(og.TypeNames, e.TypeNames...)
+			og.Directives = append(og.Directives, e.Directives...)
 
 		case *types.EnumTypeDefinition:
 			e := ext.Type.(*types.EnumTypeDefinition)
@@ -212,13 +322,14 @@ func mergeExtensions(s *types.Schema) error {
 			for _, en := range e.EnumValuesDefinition {
 				for _, on := range og.EnumValuesDefinition {
 					if on.EnumValue == en.EnumValue {
-						return fmt.Errorf("enum value %q already declared in %q", on.EnumValue, og.Name)
+						return locatedErrorf(en.Loc, "enum value %q already declared in %q", on.EnumValue, og.Name)
 					}
 				}
 			}
 			og.EnumValuesDefinition = append(og.EnumValuesDefinition, e.EnumValuesDefinition...)
+			og.Directives = append(og.Directives, e.Directives...)
 		default:
-			return fmt.Errorf(`unexpected %q, expecting "schema", "type", "enum", "interface", "union" or "input"`, og.TypeName())
+			return locatedErrorf(typeLoc(ext.Type), `unexpected %q, expecting "schema", "type", "enum", "interface", "union" or "input"`, og.TypeName())
 		}
 	}
 
@@ -264,7 +375,7 @@ func resolveDirectives(s *types.Schema, directives types.DirectiveList, loc stri
 		dirName := d.Name.Name
 		dd, ok := s.Directives[dirName]
 		if !ok {
-			return errors.Errorf("directive %q not found", dirName)
+			return locatedErrorf(d.Name.Loc, "directive %q not found", dirName)
 		}
 		validLoc := false
 		for _, l := range dd.Locations {
@@ -274,11 +385,11 @@ func resolveDirectives(s *types.Schema, directives types.DirectiveList, loc stri
 			}
 		}
 		if !validLoc {
-			return errors.Errorf("invalid location %q for directive %q (must be one of %v)", loc, dirName, dd.Locations)
+			return locatedErrorf(d.Name.Loc, "invalid location %q for directive %q (must be one of %v)", loc, dirName, dd.Locations)
 		}
 		for _, arg := range d.Arguments {
 			if dd.Arguments.Get(arg.Name.Name) == nil {
-				return errors.Errorf("invalid argument %q for directive %q", arg.Name.Name, dirName)
+				return locatedErrorf(arg.Name.Loc, "invalid argument %q for directive %q", arg.Name.Name, dirName)
 			}
 		}
 		for _, arg := range dd.Arguments {
@@ -314,43 +425,43 @@ func parseSchema(s *types.Schema, l *common.Lexer) {
 
 				name := l.ConsumeIdent()
 				l.ConsumeToken(':')
-				typ := l.ConsumeIdent()
-				s.EntryPointNames[name] = typ
+				declareEntryPoint(s, name, l)
 			}
 			l.ConsumeToken('}')
 
 		case "type":
 			obj := parseObjectDef(l)
 			obj.Desc = desc
-			s.Types[obj.Name] = obj
+			declareType(s, obj)
 			s.Objects = append(s.Objects, obj)
 
 		case "interface":
 			iface := parseInterfaceDef(l)
 			iface.Desc = desc
-			s.Types[iface.Name] = iface
+			declareType(s, iface)
 
 		case "union":
 			union := parseUnionDef(l)
 			union.Desc = desc
-			s.Types[union.Name] = union
+			declareType(s, union)
 			s.Unions = append(s.Unions, union)
 
 		case "enum":
 			enum := parseEnumDef(l)
 			enum.Desc = desc
-			s.Types[enum.Name] = enum
+			declareType(s, enum)
 			s.Enums = append(s.Enums, enum)
 
 		case "input":
 			input := parseInputDef(l)
 			input.Desc = desc
-			s.Types[input.Name] = input
+			declareType(s, input)
 
 		case "scalar":
+			loc := l.Location()
 			name := l.ConsumeIdent()
 			directives := common.ParseDirectives(l)
-			s.Types[name] = &types.ScalarTypeDefinition{Name: name, Desc: desc, Directives: directives}
+			declareType(s, &types.ScalarTypeDefinition{Name: name, Desc: desc, Directives: directives, Loc: loc})
 
 		case "directive":
 			directive := parseDirectiveDef(l)
@@ -368,7 +479,7 @@ func parseSchema(s *types.Schema, l *common.Lexer) {
 }
 
 func parseObjectDef(l *common.Lexer) *types.ObjectTypeDefinition {
-	object := &types.ObjectTypeDefinition{Name: l.ConsumeIdent()}
+	object := &types.ObjectTypeDefinition{Loc: l.Location(), Name: l.ConsumeIdent()}
 
 	for {
 		if l.Peek() == '{' {
@@ -403,7 +514,7 @@ func parseObjectDef(l *common.Lexer) *types.ObjectTypeDefinition {
 }
 
 func parseInterfaceDef(l *common.Lexer) *types.InterfaceTypeDefinition {
-	i := &types.InterfaceTypeDefinition{Name: l.ConsumeIdent()}
+	i := &types.InterfaceTypeDefinition{Loc: l.Location(), Name: l.ConsumeIdent()}
 
 	i.Directives = common.ParseDirectives(l)
 
@@ -415,7 +526,7 @@ func parseInterfaceDef(l *common.Lexer) *types.InterfaceTypeDefinition {
 }
 
 func parseUnionDef(l *common.Lexer) *types.Union {
-	union := &types.Union{Name: l.ConsumeIdent()}
+	union := &types.Union{Loc: l.Location(), Name: l.ConsumeIdent()}
 
 	union.Directives = common.ParseDirectives(l)
 	l.ConsumeToken('=')
@@ -430,6 +541,7 @@ func parseUnionDef(l *common.Lexer) *types.Union {
 
 func parseInputDef(l *common.Lexer) *types.InputObject {
 	i := &types.InputObject{}
+	i.Loc = l.Location()
 	i.Name = l.ConsumeIdent()
 	i.Directives = common.ParseDirectives(l)
 	l.ConsumeToken('{')
@@ -441,13 +553,14 @@ func parseInputDef(l *common.Lexer) *types.InputObject {
 }
 
 func parseEnumDef(l *common.Lexer) *types.EnumTypeDefinition {
-	enum := &types.EnumTypeDefinition{Name: l.ConsumeIdent()}
+	enum := &types.EnumTypeDefinition{Loc: l.Location(), Name: l.ConsumeIdent()}
 
 	enum.Directives = common.ParseDirectives(l)
 	l.ConsumeToken('{')
 	for l.Peek() != '}' {
 		v := &types.EnumValueDefinition{
 			Desc:       l.DescComment(),
+			Loc:        l.Location(),
 			EnumValue:  l.ConsumeIdent(),
 			Directives: common.ParseDirectives(l),
 		}
@@ -459,7 +572,7 @@ func parseEnumDef(l *common.Lexer) *types.EnumTypeDefinition {
 }
 func parseDirectiveDef(l *common.Lexer) *types.DirectiveDefinition {
 	l.ConsumeToken('@')
-	d := &types.DirectiveDefinition{Name: l.ConsumeIdent()}
+	d := &types.DirectiveDefinition{Loc: l.Location(), Name: l.ConsumeIdent()}
 
 	if l.Peek() == '(' {
 		l.ConsumeToken('(')
@@ -490,8 +603,7 @@ func parseExtension(s *types.Schema, l *common.Lexer) {
 		for l.Peek() != '}' {
 			name := l.ConsumeIdent()
 			l.ConsumeToken(':')
-			typ := l.ConsumeIdent()
-			s.EntryPointNames[name] = typ
+			declareEntryPoint(s, name, l)
 		}
 		l.ConsumeToken('}')
 
@@ -526,6 +638,7 @@ func parseFieldsDef(l *common.Lexer) types.FieldsDefinition {
 	for l.Peek() != '}' {
 		f := &types.FieldDefinition{}
 		f.Desc = l.DescComment()
+		f.Loc = l.Location()
 		f.Name = l.ConsumeIdent()
 		if l.Peek() == '(' {
 			l.ConsumeToken('(')
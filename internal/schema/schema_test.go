@@ -47,7 +47,7 @@ func TestParse(t *testing.T) {
 				if err == nil {
 					return fmt.Errorf("want error, have <nil>")
 				}
-				if want, have := `graphql: interface "Greeting" expects field "message" but "Welcome" does not provide it`, err.Error(); want != have {
+				if want, have := `graphql: interface "Greeting" expects field "message" but "Welcome" does not provide it (line 5, column 9)`, err.Error(); want != have {
 					return fmt.Errorf("unexpected error: want %q, have %q", want, have)
 				}
 				return nil
@@ -528,7 +528,7 @@ Second line of the description.
 				name: String!
 			}`,
 			validateError: func(err error) error {
-				msg := `trying to extend type "OBJECT" with type "INTERFACE"`
+				msg := `graphql: trying to extend type "OBJECT" with type "INTERFACE" (line 6, column 21)`
 				if err == nil || err.Error() != msg {
 					return fmt.Errorf("expected error %q, but got %q", msg, err)
 				}
@@ -548,7 +548,7 @@ Second line of the description.
 			extend type Product implements Named {
 			}`,
 			validateError: func(err error) error {
-				msg := `interface "Named" implemented in the extension is already implemented in "Product"`
+				msg := `graphql: interface "Named" implemented in the extension is already implemented in "Product" (line 9, column 16)`
 				if err == nil || err.Error() != msg {
 					return fmt.Errorf("expected error %q, but got %q", msg, err)
 				}
@@ -571,7 +571,7 @@ Second line of the description.
 			extend union Item = Coloured | Named
 			`,
 			validateError: func(err error) error {
-				msg := `union type "Named" already declared in "Item"`
+				msg := `graphql: union type "Named" already declared in "Item" (line 12, column 17)`
 				if err == nil || err.Error() != msg {
 					return fmt.Errorf("expected error %q, but got %q", msg, err)
 				}
@@ -676,7 +676,7 @@ Second line of the description.
 				AUD
 			}`,
 			validateError: func(err error) error {
-				msg := `enum value "AUD" already declared in "Currencies"`
+				msg := `graphql: enum value "AUD" already declared in "Currencies" (line 8, column 5)`
 				if err == nil || err.Error() != msg {
 					return fmt.Errorf("expected error %q, but got %q", msg, err)
 				}
@@ -693,7 +693,7 @@ Second line of the description.
 				name: String!
 			}`,
 			validateError: func(err error) error {
-				msg := `extended field {"name" {'\x06' '\x05'}} already exists`
+				msg := `graphql: extended field "name" already exists (line 6, column 5)`
 				if err == nil || err.Error() != msg {
 					return fmt.Errorf("expected error %q, but got %q", msg, err)
 				}
@@ -714,7 +714,7 @@ Second line of the description.
 				name: String!
 			}`,
 			validateError: func(err error) error {
-				msg := `extended field "name" already exists`
+				msg := `graphql: extended field "name" already exists (line 10, column 5)`
 				if err == nil || err.Error() != msg {
 					return fmt.Errorf("expected error %q, but got %q", msg, err)
 				}
@@ -761,7 +761,7 @@ Second line of the description.
 			}
 			`,
 			validateError: func(err error) error {
-				msg := `trying to extend unknown type "User"`
+				msg := `graphql: trying to extend unknown type "User" (line 2, column 16)`
 				if err == nil || err.Error() != msg {
 					return fmt.Errorf("expected error %q, but got %q", msg, err)
 				}
@@ -873,3 +873,118 @@ Second line of the description.
 		})
 	}
 }
+
+func TestParseSources(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		sources        []schema.Source
+		validateError  func(err error) error
+		validateSchema func(s *types.Schema) error
+	}{
+		{
+			name: "Merges types and extensions across sources",
+			sources: []schema.Source{
+				{Name: "query.graphql", Body: "type Query { user: User }"},
+				{Name: "user.graphql", Body: "extend type User { email: String }\ntype User { name: String }"},
+			},
+			validateSchema: func(s *types.Schema) error {
+				user, ok := s.Types["User"].(*types.ObjectTypeDefinition)
+				if !ok {
+					return fmt.Errorf("type %q not found", "User")
+				}
+				if want, have := []string{"name", "email"}, user.Fields.Names(); fmt.Sprint(want) != fmt.Sprint(have) {
+					return fmt.Errorf("invalid fields: want %v, have %v", want, have)
+				}
+				if _, ok := s.EntryPoints["query"]; !ok {
+					return fmt.Errorf("query entry point not found")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Reports the source of syntax errors",
+			sources: []schema.Source{
+				{Name: "query.graphql", Body: "type Query { user: User }"},
+				{Name: "user.graphql", Body: "type User {\n  name String\n}"},
+			},
+			validateError: func(err error) error {
+				if want, have := `graphql: syntax error: unexpected "String", expecting ":" (user.graphql: line 2, column 8)`, err.Error(); want != have {
+					return fmt.Errorf("unexpected error: want %q, have %q", want, have)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Reports the source of unknown types",
+			sources: []schema.Source{
+				{Name: "query.graphql", Body: "type Query {\n  user: User\n}"},
+				{Name: "user.graphql", Body: "type Person { name: String }"},
+			},
+			validateError: func(err error) error {
+				if want, have := `graphql: Unknown type "User". (query.graphql: line 2, column 9)`, err.Error(); want != have {
+					return fmt.Errorf("unexpected error: want %q, have %q", want, have)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Reports types defined in several sources",
+			sources: []schema.Source{
+				{Name: "query.graphql", Body: "type Query { user: User }\ntype User { name: String }"},
+				{Name: "user.graphql", Body: "type User { email: String }"},
+			},
+			validateError: func(err error) error {
+				if want, have := `graphql: type "User" is defined more than once (query.graphql: line 2, column 6) (user.graphql: line 1, column 6)`, err.Error(); want != have {
+					return fmt.Errorf("unexpected error: want %q, have %q", want, have)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Reports the source of unknown extended types",
+			sources: []schema.Source{
+				{Name: "query.graphql", Body: "type Query { name: String }"},
+				{Name: "user.graphql", Body: "extend type User { email: String }"},
+			},
+			validateError: func(err error) error {
+				if want, have := `graphql: trying to extend unknown type "User" (user.graphql: line 1, column 13)`, err.Error(); want != have {
+					return fmt.Errorf("unexpected error: want %q, have %q", want, have)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Reports the source of unknown interfaces",
+			sources: []schema.Source{
+				{Name: "query.graphql", Body: "type Query { user: User }"},
+				{Name: "user.graphql", Body: "type User implements Node { name: String }"},
+			},
+			validateError: func(err error) error {
+				if want, have := `graphql: interface "Node" not found (user.graphql: line 1, column 6)`, err.Error(); want != have {
+					return fmt.Errorf("unexpected error: want %q, have %q", want, have)
+				}
+				return nil
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := schema.New()
+			err := schema.ParseSources(s, test.sources, false)
+			if err != nil {
+				if test.validateError == nil {
+					t.Fatal(err)
+				}
+				if err := test.validateError(err); err != nil {
+					t.Fatal(err)
+				}
+			} else if test.validateError != nil {
+				t.Fatal("want error, have <nil>")
+			}
+			if test.validateSchema != nil {
+				if err := test.validateSchema(s); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// ScalarUnmarshaler checks an input value of a custom scalar, usually by unmarshaling it into the
+// Go types bound to the scalar.
+type ScalarUnmarshaler func(t *types.ScalarTypeDefinition, value interface{}) error
+
+// coercer coerces the value of a single variable and collects the errors. It rejects values which
+// do not match the type of the variable, applies the default values of the variable and of input
+// fields and wraps single values given for lists into a list. The variables are not modified.
+type coercer struct {
+	unmarshal ScalarUnmarshaler
+	v         *types.InputValueDefinition
+	errs      []*errors.QueryError
+}
+
+func (c *coercer) addErr(format string, a ...interface{}) {
+	c.errs = append(c.errs, &errors.QueryError{
+		Message:   fmt.Sprintf(format, a...),
+		Locations: []errors.Location{c.v.Loc},
+		Rule:      "VariablesOfCorrectType",
+	})
+}
+
+func (c *coercer) addValueErr(path []interface{}, value interface{}, format string, a ...interface{}) {
+	at := ""
+	if len(path) > 0 {
+		at = fmt.Sprintf(" at %q", formatPath(c.v.Name.Name, path))
+	}
+	c.addErr("Variable %q got invalid value %s%s; %s", "$"+c.v.Name.Name, inspect(value), at, fmt.Sprintf(format, a...))
+}
+
+// coerceVariable returns the coerced value of the variable and whether it is given at all.
+func (c *coercer) coerceVariable(t types.Type, variables map[string]interface{}) (interface{}, bool) {
+	value, ok := variables[c.v.Name.Name]
+	if !ok {
+		if c.v.Default != nil {
+			return c.v.Default.Deserialize(nil), true
+		}
+		if _, ok := t.(*types.NonNull); ok {
+			c.addErr("Variable %q of required type %q was not provided.", "$"+c.v.Name.Name, t)
+		}
+		return nil, false
+	}
+	if value == nil {
+		if _, ok := t.(*types.NonNull); ok {
+			c.addErr("Variable %q of non-null type %q must not be null.", "$"+c.v.Name.Name, t)
+		}
+		return nil, true
+	}
+	return c.coerceValue(t, value, nil), true
+}
+
+func (c *coercer) coerceValue(t types.Type, value interface{}, path []interface{}) interface{} {
+	if nn, ok := t.(*types.NonNull); ok {
+		if value == nil {
+			c.addValueErr(path, value, "Expected non-nullable type %q not to be null.", t)
+			return nil
+		}
+		t = nn.OfType
+	}
+	if value == nil {
+		return nil
+	}
+
+	switch t := t.(type) {
+	case *types.List:
+		list, ok := value.([]interface{})
+		if !ok {
+			// Input coercion rules allow single items without wrapping array
+			return []interface{}{c.coerceValue(t.OfType, value, path)}
+		}
+		coerced := make([]interface{}, len(list))
+		for i, entry := range list {
+			coerced[i] = c.coerceValue(t.OfType, entry, appendPath(path, i))
+		}
+		return coerced
+
+	case *types.InputObject:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			c.addValueErr(path, value, "Expected type %q to be an object.", t)
+			return value
+		}
+		coerced := make(map[string]interface{}, len(fields))
+		for _, f := range t.Values {
+			fieldValue, ok := fields[f.Name.Name]
+			if !ok {
+				if f.Default != nil {
+					coerced[f.Name.Name] = f.Default.Deserialize(nil)
+				} else if _, ok := f.Type.(*types.NonNull); ok {
+					c.addValueErr(path, value, "Field %q of required type %q was not provided.", f.Name.Name, f.Type)
+				}
+				continue
+			}
+			coerced[f.Name.Name] = c.coerceValue(f.Type, fieldValue, appendPath(path, f.Name.Name))
+		}
+		var unknown []string
+		for name := range fields {
+			if t.Values.Get(name) == nil {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		for _, name := range unknown {
+			c.addValueErr(path, value, "Field %q is not defined by type %q.", name, t)
+		}
+		return coerced
+
+	case *types.EnumTypeDefinition:
+		name, ok := value.(string)
+		if !ok {
+			c.addValueErr(path, value, "Enum %q cannot represent non-string value: %s.", t, inspect(value))
+			return value
+		}
+		for _, option := range t.EnumValuesDefinition {
+			if option.EnumValue == name {
+				return value
+			}
+		}
+		c.addValueErr(path, value, "Value %q does not exist in %q enum.", name, t)
+		return value
+
+	case *types.ScalarTypeDefinition:
+		if reason := checkScalar(t, value, c.unmarshal); reason != "" {
+			c.addValueErr(path, value, "%s", reason)
+		}
+		return value
+
+	default:
+		return value
+	}
+}
+
+// checkScalar returns why value can not be coerced to the scalar t, or an empty string if it can.
+func checkScalar(t *types.ScalarTypeDefinition, value interface{}, unmarshal ScalarUnmarshaler) string {
+	switch t.Name {
+	case "Int":
+		f, ok := number(value)
+		if !ok || f != math.Trunc(f) {
+			return fmt.Sprintf("Int cannot represent non-integer value: %s", inspect(value))
+		}
+		if f < math.MinInt32 || f > math.MaxInt32 {
+			return fmt.Sprintf("Int cannot represent non 32-bit signed integer value: %s", inspect(value))
+		}
+	case "Float":
+		if _, ok := number(value); !ok {
+			return fmt.Sprintf("Float cannot represent non numeric value: %s", inspect(value))
+		}
+	case "String":
+		if _, ok := value.(string); !ok {
+			return fmt.Sprintf("String cannot represent a non string value: %s", inspect(value))
+		}
+	case "Boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("Boolean cannot represent a non boolean value: %s", inspect(value))
+		}
+	case "ID":
+		if _, ok := value.(string); ok {
+			return ""
+		}
+		if f, ok := number(value); !ok || f != math.Trunc(f) {
+			return fmt.Sprintf("ID cannot represent value: %s", inspect(value))
+		}
+	default:
+		if unmarshal != nil {
+			if err := unmarshal(t, value); err != nil {
+				return fmt.Sprintf("Expected type %q. %s", t, err)
+			}
+		}
+	}
+	return ""
+}
+
+// number returns the value of a number as decoded from JSON or passed by Go code.
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := strconv.ParseFloat(string(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func appendPath(path []interface{}, segment interface{}) []interface{} {
+	return append(path[:len(path):len(path)], segment)
+}
+
+// formatPath formats the path of a value within a variable like "input.items[2].qty".
+func formatPath(name string, path []interface{}) string {
+	var b strings.Builder
+	b.WriteString(name)
+	for _, p := range path {
+		switch p := p.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%d]", p)
+		default:
+			fmt.Fprintf(&b, ".%s", p)
+		}
+	}
+	return b.String()
+}
+
+// inspect formats a variable value like JSON.
+func inspect(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
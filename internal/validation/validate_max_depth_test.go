@@ -78,7 +78,7 @@ func (tc maxDepthTestCase) Run(t *testing.T, s *types.Schema) {
 			t.Fatal(qErr)
 		}
 
-		errs := Validate(s, doc, nil, tc.depth)
+		_, errs := Validate(s, doc, nil, tc.depth, nil)
 		if len(tc.expectedErrors) > 0 {
 			if len(errs) > 0 {
 				for _, expected := range tc.expectedErrors {
@@ -426,7 +426,7 @@ func TestMaxDepthValidation(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			context := newContext(s, doc, tc.maxDepth)
+			context := newContext(s, doc, tc.maxDepth, nil)
 			op := doc.Operations[0]
 
 			opc := &opContext{context: context, ops: doc.Operations}
@@ -34,6 +34,7 @@ type context struct {
 	fieldMap         map[*types.Field]fieldInfo
 	overlapValidated map[selectionPair]struct{}
 	maxDepth         int
+	unmarshal        ScalarUnmarshaler
 }
 
 func (c *context) addErr(loc errors.Location, rule string, format string, a ...interface{}) {
@@ -53,7 +54,7 @@ type opContext struct {
 	ops []*types.OperationDefinition
 }
 
-func newContext(s *types.Schema, doc *types.ExecutableDefinition, maxDepth int) *context {
+func newContext(s *types.Schema, doc *types.ExecutableDefinition, maxDepth int, unmarshal ScalarUnmarshaler) *context {
 	return &context{
 		schema:           s,
 		doc:              doc,
@@ -62,11 +63,16 @@ func newContext(s *types.Schema, doc *types.ExecutableDefinition, maxDepth int)
 		fieldMap:         make(map[*types.Field]fieldInfo),
 		overlapValidated: make(map[selectionPair]struct{}),
 		maxDepth:         maxDepth,
+		unmarshal:        unmarshal,
 	}
 }
 
-func Validate(s *types.Schema, doc *types.ExecutableDefinition, variables map[string]interface{}, maxDepth int) []*errors.QueryError {
-	c := newContext(s, doc, maxDepth)
+// Validate validates doc with the schema s. It also coerces the variables of each operation as
+// described in https://spec.graphql.org/October2021/#CoerceVariableValues() and returns their
+// coerced values. The values of custom scalars are checked by unmarshal, which may be nil.
+func Validate(s *types.Schema, doc *types.ExecutableDefinition, variables map[string]interface{}, maxDepth int, unmarshal ScalarUnmarshaler) (map[*types.OperationDefinition]map[string]interface{}, []*errors.QueryError) {
+	c := newContext(s, doc, maxDepth, unmarshal)
+	coerced := make(map[*types.OperationDefinition]map[string]interface{}, len(doc.Operations))
 
 	opNames := make(nameSet)
 	fragUsedBy := make(map[*types.FragmentDefinition][]*types.OperationDefinition)
@@ -77,7 +83,7 @@ func Validate(s *types.Schema, doc *types.ExecutableDefinition, variables map[st
 		// Check if max depth is exceeded, if it's set. If max depth is exceeded,
 		// don't continue to validate the document and exit early.
 		if validateMaxDepth(opc, op.Selections, 1) {
-			return c.errs
+			return coerced, c.errs
 		}
 
 		if op.Name.Name == "" && len(doc.Operations) != 1 {
@@ -90,6 +96,7 @@ func Validate(s *types.Schema, doc *types.ExecutableDefinition, variables map[st
 		validateDirectives(opc, string(op.Type), op.Directives)
 
 		varNames := make(nameSet)
+		coerced[op] = make(map[string]interface{}, len(op.Vars))
 		for _, v := range op.Vars {
 			validateName(c, varNames, v.Name, "UniqueVariableNames", "variable")
 
@@ -97,7 +104,13 @@ func Validate(s *types.Schema, doc *types.ExecutableDefinition, variables map[st
 			if !canBeInput(t) {
 				c.addErr(v.TypeLoc, "VariablesAreInputTypes", "Variable %q cannot be non-input type %q.", "$"+v.Name.Name, t)
 			}
-			validateValue(opc, v, variables[v.Name.Name], t)
+			if t != nil {
+				vc := &coercer{unmarshal: unmarshal, v: v}
+				if value, ok := vc.coerceVariable(t, variables); ok {
+					coerced[op][v.Name.Name] = value
+				}
+				c.errs = append(c.errs, vc.errs...)
+			}
 
 			if v.Default != nil {
 				validateLiteral(opc, v.Default)
@@ -178,59 +191,7 @@ func Validate(s *types.Schema, doc *types.ExecutableDefinition, variables map[st
 		}
 	}
 
-	return c.errs
-}
-
-func validateValue(c *opContext, v *types.InputValueDefinition, val interface{}, t types.Type) {
-	switch t := t.(type) {
-	case *types.NonNull:
-		if val == nil {
-			c.addErr(v.Loc, "VariablesOfCorrectType", "Variable \"%s\" has invalid value null.\nExpected type \"%s\", found null.", v.Name.Name, t)
-			return
-		}
-		validateValue(c, v, val, t.OfType)
-	case *types.List:
-		if val == nil {
-			return
-		}
-		vv, ok := val.([]interface{})
-		if !ok {
-			// Input coercion rules allow single items without wrapping array
-			validateValue(c, v, val, t.OfType)
-			return
-		}
-		for _, elem := range vv {
-			validateValue(c, v, elem, t.OfType)
-		}
-	case *types.EnumTypeDefinition:
-		if val == nil {
-			return
-		}
-		e, ok := val.(string)
-		if !ok {
-			c.addErr(v.Loc, "VariablesOfCorrectType", "Variable \"%s\" has invalid type %T.\nExpected type \"%s\", found %v.", v.Name.Name, val, t, val)
-			return
-		}
-		for _, option := range t.EnumValuesDefinition {
-			if option.EnumValue == e {
-				return
-			}
-		}
-		c.addErr(v.Loc, "VariablesOfCorrectType", "Variable \"%s\" has invalid value %s.\nExpected type \"%s\", found %s.", v.Name.Name, e, t, e)
-	case *types.InputObject:
-		if val == nil {
-			return
-		}
-		in, ok := val.(map[string]interface{})
-		if !ok {
-			c.addErr(v.Loc, "VariablesOfCorrectType", "Variable \"%s\" has invalid type %T.\nExpected type \"%s\", found %s.", v.Name.Name, val, t, val)
-			return
-		}
-		for _, f := range t.Values {
-			fieldVal := in[f.Name.Name]
-			validateValue(c, f, fieldVal, f.Type)
-		}
-	}
+	return coerced, c.errs
 }
 
 // validates the query doesn't go deeper than maxDepth (if set). Returns whether
@@ -779,6 +740,16 @@ func validateValueType(c *opContext, v types.Value, t types.Type) (bool, string)
 				return true, ""
 			}
 		}
+		// Custom scalars like JSON may accept lists and objects, if the Go types bound to them do.
+		if t, ok := t.(*types.ScalarTypeDefinition); ok && c.unmarshal != nil && !isBuiltinScalar(t.Name) {
+			switch v.(type) {
+			case *types.ListValue, *types.ObjectValue:
+				if err := c.unmarshal(t, v.Deserialize(nil)); err != nil {
+					return false, fmt.Sprintf("Expected type %q, found %s; %s", t, v, err)
+				}
+				return true, ""
+			}
+		}
 
 	case *types.List:
 		list, ok := v.(*types.ListValue)
@@ -827,6 +798,14 @@ func validateValueType(c *opContext, v types.Value, t types.Type) (bool, string)
 	return false, fmt.Sprintf("Expected type %q, found %s.", t, v)
 }
 
+func isBuiltinScalar(name string) bool {
+	switch name {
+	case "Int", "Float", "String", "Boolean", "ID":
+		return true
+	}
+	return false
+}
+
 func validateBasicLit(v *types.PrimitiveValue, t types.Type) bool {
 	switch t := t.(type) {
 	case *types.ScalarTypeDefinition:
@@ -836,12 +815,13 @@ func validateBasicLit(v *types.PrimitiveValue, t types.Type) bool {
 				return false
 			}
 			f, err := strconv.ParseFloat(v.Text, 64)
-			if err != nil {
-				panic(err)
-			}
-			return f >= math.MinInt32 && f <= math.MaxInt32
+			return err == nil && f >= math.MinInt32 && f <= math.MaxInt32
 		case "Float":
-			return v.Type == scanner.Int || v.Type == scanner.Float
+			if v.Type != scanner.Int && v.Type != scanner.Float {
+				return false
+			}
+			_, err := strconv.ParseFloat(v.Text, 64)
+			return err == nil
 		case "String":
 			return v.Type == scanner.String
 		case "Boolean":
@@ -53,7 +53,7 @@ func TestValidate(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			errs := validation.Validate(schemas[test.Schema], d, test.Vars, 0)
+			_, errs := validation.Validate(schemas[test.Schema], d, test.Vars, 0, nil)
 			got := []*errors.QueryError{}
 			for _, err := range errs {
 				if err.Rule == test.Rule {
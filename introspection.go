@@ -13,6 +13,17 @@ func (s *Schema) Inspect() *introspection.Schema {
 	return introspection.WrapSchema(s.schema)
 }
 
+// ParseIntrospection creates a schema from the result of an introspection query, such as the
+// output of ToJSON. The schema has no resolver, so it can not be executed, but queries may be
+// validated against it and it may be inspected.
+func ParseIntrospection(data []byte, opts ...SchemaOpt) (*Schema, error) {
+	typeSystem, err := introspection.BuildSchema(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewSchema(typeSystem, nil, opts...)
+}
+
 // ToJSON encodes the schema in a JSON format used by tools like Relay.
 func (s *Schema) ToJSON() ([]byte, error) {
 	result := s.exec(context.Background(), introspectionQuery, "", nil, &resolvable.Schema{
@@ -76,6 +87,7 @@ var introspectionQuery = `
     possibleTypes {
       ...TypeRef
     }
+    specifiedByURL
   }
   fragment InputValue on __InputValue {
     name
@@ -0,0 +1,446 @@
+package introspection
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/scanner"
+
+	"github.com/graph-gophers/graphql-go/internal/common"
+	"github.com/graph-gophers/graphql-go/internal/schema"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// BuildSchema constructs a type system from the result of an introspection query, such as the
+// output of Schema.ToJSON. The document may be the introspection result itself
+// ({"__schema": ...}) or a complete GraphQL response ({"data": {"__schema": ...}}). Built-in
+// scalars, introspection types and built-in directives of the document are ignored in favor of
+// the ones of this package.
+func BuildSchema(data []byte) (*types.Schema, error) {
+	var doc struct {
+		Data   *introspectionResult `json:"data"`
+		Schema *jsonSchema          `json:"__schema"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid introspection result: %s", err)
+	}
+	js := doc.Schema
+	if doc.Data != nil {
+		js = doc.Data.Schema
+	}
+	if js == nil {
+		return nil, fmt.Errorf("invalid introspection result: missing __schema")
+	}
+
+	s := schema.New()
+	if err := js.build(s); err != nil {
+		return nil, err
+	}
+	if err := checkReferences(s); err != nil {
+		return nil, err
+	}
+	if err := schema.Resolve(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+type introspectionResult struct {
+	Schema *jsonSchema `json:"__schema"`
+}
+
+type jsonSchema struct {
+	QueryType        *jsonType        `json:"queryType"`
+	MutationType     *jsonType        `json:"mutationType"`
+	SubscriptionType *jsonType        `json:"subscriptionType"`
+	Types            []*jsonType      `json:"types"`
+	Directives       []*jsonDirective `json:"directives"`
+}
+
+type jsonType struct {
+	Kind           string            `json:"kind"`
+	Name           *string           `json:"name"`
+	Description    *string           `json:"description"`
+	Fields         []*jsonField      `json:"fields"`
+	InputFields    []*jsonInputValue `json:"inputFields"`
+	Interfaces     []*jsonType       `json:"interfaces"`
+	EnumValues     []*jsonEnumValue  `json:"enumValues"`
+	PossibleTypes  []*jsonType       `json:"possibleTypes"`
+	OfType         *jsonType         `json:"ofType"`
+	SpecifiedByURL *string           `json:"specifiedByURL"`
+}
+
+type jsonField struct {
+	Name              string            `json:"name"`
+	Description       *string           `json:"description"`
+	Args              []*jsonInputValue `json:"args"`
+	Type              *jsonType         `json:"type"`
+	IsDeprecated      bool              `json:"isDeprecated"`
+	DeprecationReason *string           `json:"deprecationReason"`
+}
+
+type jsonInputValue struct {
+	Name         string    `json:"name"`
+	Description  *string   `json:"description"`
+	Type         *jsonType `json:"type"`
+	DefaultValue *string   `json:"defaultValue"`
+}
+
+type jsonEnumValue struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+type jsonDirective struct {
+	Name        string            `json:"name"`
+	Description *string           `json:"description"`
+	Locations   []string          `json:"locations"`
+	Args        []*jsonInputValue `json:"args"`
+}
+
+var builtinScalars = map[string]bool{
+	"Int":     true,
+	"Float":   true,
+	"String":  true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+func (js *jsonSchema) build(s *types.Schema) error {
+	for op, t := range map[string]*jsonType{"query": js.QueryType, "mutation": js.MutationType, "subscription": js.SubscriptionType} {
+		if t != nil && t.Name != nil {
+			s.EntryPointNames[op] = *t.Name
+		}
+	}
+
+	for _, jt := range js.Types {
+		if jt.Name == nil {
+			return fmt.Errorf("invalid introspection result: type of kind %s without name", jt.Kind)
+		}
+		name := *jt.Name
+		if strings.HasPrefix(name, "__") || builtinScalars[name] {
+			continue
+		}
+		t, err := jt.buildNamed()
+		if err != nil {
+			return fmt.Errorf("type %q: %s", name, err)
+		}
+		s.Types[name] = t
+		switch t := t.(type) {
+		case *types.ObjectTypeDefinition:
+			s.Objects = append(s.Objects, t)
+		case *types.Union:
+			s.Unions = append(s.Unions, t)
+		case *types.EnumTypeDefinition:
+			s.Enums = append(s.Enums, t)
+		}
+	}
+
+	// Possible types of interfaces are reported in the order of the object definitions, so that
+	// order is restored from the introspection result.
+	rank := make(map[string]int)
+	for _, jt := range js.Types {
+		if jt.Kind != "INTERFACE" {
+			continue
+		}
+		for _, pt := range jt.PossibleTypes {
+			if _, ok := rank[stringValue(pt.Name)]; !ok {
+				rank[stringValue(pt.Name)] = len(rank)
+			}
+		}
+	}
+	sort.SliceStable(s.Objects, func(i, j int) bool {
+		ri, iok := rank[s.Objects[i].Name]
+		rj, jok := rank[s.Objects[j].Name]
+		if iok && jok {
+			return ri < rj
+		}
+		return iok && !jok
+	})
+
+	for _, jd := range js.Directives {
+		if _, ok := s.Directives[jd.Name]; ok {
+			continue
+		}
+		args, err := buildInputValues(jd.Args)
+		if err != nil {
+			return fmt.Errorf("directive %q: %s", jd.Name, err)
+		}
+		s.Directives[jd.Name] = &types.DirectiveDefinition{
+			Name:      jd.Name,
+			Desc:      stringValue(jd.Description),
+			Locations: jd.Locations,
+			Arguments: args,
+		}
+	}
+	return nil
+}
+
+func (jt *jsonType) buildNamed() (types.NamedType, error) {
+	name, desc := *jt.Name, stringValue(jt.Description)
+	switch jt.Kind {
+	case "SCALAR":
+		return &types.ScalarTypeDefinition{Name: name, Desc: desc, Directives: specifiedBy(jt.SpecifiedByURL)}, nil
+
+	case "OBJECT":
+		fields, err := buildFields(jt.Fields)
+		if err != nil {
+			return nil, err
+		}
+		obj := &types.ObjectTypeDefinition{Name: name, Desc: desc, Fields: fields}
+		for _, intf := range jt.Interfaces {
+			if intf.Name == nil {
+				return nil, fmt.Errorf("interface without name")
+			}
+			obj.InterfaceNames = append(obj.InterfaceNames, *intf.Name)
+		}
+		return obj, nil
+
+	case "INTERFACE":
+		fields, err := buildFields(jt.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return &types.InterfaceTypeDefinition{Name: name, Desc: desc, Fields: fields}, nil
+
+	case "UNION":
+		union := &types.Union{Name: name, Desc: desc}
+		for _, member := range jt.PossibleTypes {
+			if member.Name == nil {
+				return nil, fmt.Errorf("possible type without name")
+			}
+			union.TypeNames = append(union.TypeNames, *member.Name)
+		}
+		return union, nil
+
+	case "ENUM":
+		enum := &types.EnumTypeDefinition{Name: name, Desc: desc}
+		for _, v := range jt.EnumValues {
+			enum.EnumValuesDefinition = append(enum.EnumValuesDefinition, &types.EnumValueDefinition{
+				EnumValue:  v.Name,
+				Desc:       stringValue(v.Description),
+				Directives: deprecation(v.IsDeprecated, v.DeprecationReason),
+			})
+		}
+		return enum, nil
+
+	case "INPUT_OBJECT":
+		values, err := buildInputValues(jt.InputFields)
+		if err != nil {
+			return nil, err
+		}
+		return &types.InputObject{Name: name, Desc: desc, Values: values}, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected kind %s", jt.Kind)
+	}
+}
+
+// checkReferences reports a reference to a type which is not part of the introspection result,
+// naming the schema element it comes from. The built types have no locations, so the errors of
+// schema.Resolve would not tell where the reference is.
+func checkReferences(s *types.Schema) error {
+	names := make([]string, 0, len(s.Types))
+	for name := range s.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		switch t := s.Types[name].(type) {
+		case *types.ObjectTypeDefinition:
+			for _, intf := range t.InterfaceNames {
+				if _, ok := s.Types[intf].(*types.InterfaceTypeDefinition); !ok {
+					return fmt.Errorf("type %q: unknown interface %q", name, intf)
+				}
+			}
+			if err := checkFieldReferences(s, name, t.Fields); err != nil {
+				return err
+			}
+		case *types.InterfaceTypeDefinition:
+			if err := checkFieldReferences(s, name, t.Fields); err != nil {
+				return err
+			}
+		case *types.Union:
+			for _, member := range t.TypeNames {
+				if _, ok := s.Types[member].(*types.ObjectTypeDefinition); !ok {
+					return fmt.Errorf("type %q: unknown member type %q", name, member)
+				}
+			}
+		case *types.InputObject:
+			for _, v := range t.Values {
+				if err := checkReference(s, v.Type); err != nil {
+					return fmt.Errorf("input field \"%s.%s\": %s", name, v.Name.Name, err)
+				}
+			}
+		}
+	}
+
+	names = names[:0]
+	for name := range s.Directives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, arg := range s.Directives[name].Arguments {
+			if err := checkReference(s, arg.Type); err != nil {
+				return fmt.Errorf("argument \"@%s(%s:)\": %s", name, arg.Name.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func checkFieldReferences(s *types.Schema, typeName string, fields types.FieldsDefinition) error {
+	for _, f := range fields {
+		if err := checkReference(s, f.Type); err != nil {
+			return fmt.Errorf("field \"%s.%s\": %s", typeName, f.Name, err)
+		}
+		for _, arg := range f.Arguments {
+			if err := checkReference(s, arg.Type); err != nil {
+				return fmt.Errorf("argument \"%s.%s(%s:)\": %s", typeName, f.Name, arg.Name.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func checkReference(s *types.Schema, t types.Type) error {
+	switch t := t.(type) {
+	case *types.List:
+		return checkReference(s, t.OfType)
+	case *types.NonNull:
+		return checkReference(s, t.OfType)
+	case *types.TypeName:
+		if _, ok := s.Types[t.Name]; !ok {
+			return fmt.Errorf("unknown type %q", t.Name)
+		}
+	}
+	return nil
+}
+
+// buildRef converts a type reference, which may wrap a named type in lists and non-null types.
+// The named type is resolved by schema.Resolve.
+func (jt *jsonType) buildRef() (types.Type, error) {
+	if jt == nil {
+		return nil, fmt.Errorf("missing type")
+	}
+	switch jt.Kind {
+	case "LIST":
+		t, err := jt.OfType.buildRef()
+		if err != nil {
+			return nil, err
+		}
+		return &types.List{OfType: t}, nil
+	case "NON_NULL":
+		t, err := jt.OfType.buildRef()
+		if err != nil {
+			return nil, err
+		}
+		return &types.NonNull{OfType: t}, nil
+	default:
+		if jt.Name == nil {
+			return nil, fmt.Errorf("type reference of kind %s without name", jt.Kind)
+		}
+		return &types.TypeName{Ident: types.Ident{Name: *jt.Name}}, nil
+	}
+}
+
+func buildFields(jfs []*jsonField) (types.FieldsDefinition, error) {
+	fields := make(types.FieldsDefinition, 0, len(jfs))
+	for _, jf := range jfs {
+		t, err := jf.Type.buildRef()
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %s", jf.Name, err)
+		}
+		args, err := buildInputValues(jf.Args)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %s", jf.Name, err)
+		}
+		fields = append(fields, &types.FieldDefinition{
+			Name:       jf.Name,
+			Desc:       stringValue(jf.Description),
+			Type:       t,
+			Arguments:  args,
+			Directives: deprecation(jf.IsDeprecated, jf.DeprecationReason),
+		})
+	}
+	return fields, nil
+}
+
+func buildInputValues(jvs []*jsonInputValue) (types.ArgumentsDefinition, error) {
+	var values types.ArgumentsDefinition
+	for _, jv := range jvs {
+		t, err := jv.Type.buildRef()
+		if err != nil {
+			return nil, fmt.Errorf("input value %q: %s", jv.Name, err)
+		}
+		v := &types.InputValueDefinition{
+			Name: types.Ident{Name: jv.Name},
+			Desc: stringValue(jv.Description),
+			Type: t,
+		}
+		if jv.DefaultValue != nil {
+			v.Default, err = parseLiteral(*jv.DefaultValue)
+			if err != nil {
+				return nil, fmt.Errorf("input value %q: invalid default value %q: %s", jv.Name, *jv.DefaultValue, err)
+			}
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func parseLiteral(s string) (types.Value, error) {
+	var v types.Value
+	l := common.NewLexer(s, false)
+	err := l.CatchSyntaxError(func() {
+		l.ConsumeWhitespace()
+		v = common.ParseLiteral(l, true)
+		if l.Peek() != scanner.EOF {
+			l.SyntaxError(fmt.Sprintf("unexpected input after the end of %q", s))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func deprecation(isDeprecated bool, reason *string) types.DirectiveList {
+	if !isDeprecated {
+		return nil
+	}
+	d := &types.Directive{Name: types.Ident{Name: "deprecated"}}
+	if reason != nil {
+		d.Arguments = types.ArgumentList{{
+			Name:  types.Ident{Name: "reason"},
+			Value: &types.PrimitiveValue{Type: scanner.String, Text: strconv.Quote(*reason)},
+		}}
+	}
+	return types.DirectiveList{d}
+}
+
+func specifiedBy(url *string) types.DirectiveList {
+	if url == nil {
+		return nil
+	}
+	return types.DirectiveList{{
+		Name: types.Ident{Name: "specifiedBy"},
+		Arguments: types.ArgumentList{{
+			Name:  types.Ident{Name: "url"},
+			Value: &types.PrimitiveValue{Type: scanner.String, Text: strconv.Quote(*url)},
+		}},
+	}}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -99,6 +99,19 @@ func (r *Type) Description() *string {
 	return nil
 }
 
+func (r *Type) SpecifiedByURL() *string {
+	t, ok := r.typ.(*types.ScalarTypeDefinition)
+	if !ok {
+		return nil
+	}
+	d := t.Directives.Get("specifiedBy")
+	if d == nil {
+		return nil
+	}
+	url := d.Arguments.MustGet("url").Deserialize(nil).(string)
+	return &url
+}
+
 func (r *Type) Fields(args *struct{ IncludeDeprecated bool }) *[]*Field {
 	var fields types.FieldsDefinition
 	switch t := r.typ.(type) {
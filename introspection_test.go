@@ -70,6 +70,80 @@ func TestSchema_ToJSON(t *testing.T) {
 	}
 }
 
+func TestParseIntrospection(t *testing.T) {
+	t.Parallel()
+
+	for _, filename := range []string{"example/social/introspect.json", "example/starwars/introspect.json"} {
+		t.Run(filename, func(t *testing.T) {
+			data := mustReadFile(filename)
+			schema, err := graphql.ParseIntrospection(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			j, err := schema.ToJSON()
+			if err != nil {
+				t.Fatalf("invalid schema %s", err.Error())
+			}
+			got, err := formatJSON(j)
+			if err != nil {
+				t.Fatalf("got: invalid JSON: %s", err)
+			}
+			want, err := formatJSON(data)
+			if err != nil {
+				t.Fatalf("want: invalid JSON: %s", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Logf("got:  %s", got)
+				t.Logf("want: %s", want)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestParseIntrospection_validate(t *testing.T) {
+	t.Parallel()
+
+	response := []byte(`{"data": ` + string(mustReadFile("example/starwars/introspect.json")) + `}`)
+	schema, err := graphql.ParseIntrospection(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if errs := schema.Validate(`{ hero(episode: EMPIRE) { name ... on Droid { primaryFunction } } }`); len(errs) != 0 {
+		t.Errorf("want no errors, got %v", errs)
+	}
+	errs := schema.Validate(`{ hero(episode: DEATH_STAR) { name } }`)
+	if len(errs) != 1 {
+		t.Fatalf("want 1 error, got %v", errs)
+	}
+	if want := `Argument "episode" has invalid value DEATH_STAR.
+Expected type "Episode", found DEATH_STAR.`; errs[0].Message != want {
+		t.Errorf("want %q, got %q", want, errs[0].Message)
+	}
+}
+
+func TestParseIntrospection_invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		data string
+		want string
+	}{
+		{`{}`, "invalid introspection result: missing __schema"},
+		{`{"__schema": {"types": [{"kind": "UNKNOWN", "name": "X"}]}}`, `type "X": unexpected kind UNKNOWN`},
+		{`{"__schema": {"types": [{"kind": "OBJECT", "name": "X", "fields": [{"name": "a", "type": {"kind": "OBJECT", "name": "Y"}}]}]}}`, `field "X.a": unknown type "Y"`},
+		{`{"__schema": {"types": [{"kind": "INPUT_OBJECT", "name": "X", "inputFields": [{"name": "b", "type": {"kind": "LIST", "ofType": {"kind": "INPUT_OBJECT", "name": "Y"}}}]}]}}`, `input field "X.b": unknown type "Y"`},
+		{`{"__schema": {"types": [{"kind": "OBJECT", "name": "X", "fields": [{"name": "a", "type": {"kind": "SCALAR", "name": "String"}}], "interfaces": [{"kind": "INTERFACE", "name": "Y"}]}]}}`, `type "X": unknown interface "Y"`},
+	} {
+		_, err := graphql.ParseIntrospection([]byte(tt.data))
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("want error %q, got %v", tt.want, err)
+		}
+	}
+}
+
 func formatJSON(data []byte) ([]byte, error) {
 	var v interface{}
 	if err := json.Unmarshal(data, &v); err != nil {
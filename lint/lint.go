@@ -0,0 +1,184 @@
+// Package lint checks a GraphQL schema against conventions such as naming rules, descriptions
+// and the Relay connection specification.
+//
+// Rules are pluggable: Lint accepts any value implementing Rule, so project specific checks can
+// be combined with the built-in ones returned by DefaultRules.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// Problem is a violation of a rule.
+type Problem struct {
+	// Rule is the name of the rule that reported the problem.
+	Rule string `json:"rule"`
+	// Path is the schema coordinate of the offending element, e.g. "User.name",
+	// "Query.users(first:)" or "Role.ADMIN".
+	Path     string          `json:"path"`
+	Message  string          `json:"message"`
+	Location errors.Location `json:"location"`
+	// Source is the name of the schema source the element is defined in, if any. It repeats
+	// Location.Source, which is not part of the JSON encoding of a location.
+	Source string `json:"source,omitempty"`
+}
+
+func (p Problem) String() string {
+	if p.Location.Line == 0 {
+		return fmt.Sprintf("%s: %s (%s)", p.Path, p.Message, p.Rule)
+	}
+	if p.Location.Source != "" {
+		return fmt.Sprintf("%s:%d:%d: %s (%s)", p.Location.Source, p.Location.Line, p.Location.Column, p.Message, p.Rule)
+	}
+	return fmt.Sprintf("%d:%d: %s (%s)", p.Location.Line, p.Location.Column, p.Message, p.Rule)
+}
+
+// Rule is a single check over a schema.
+type Rule interface {
+	// Name identifies the rule in reported problems, e.g. "type-names-pascal-case".
+	Name() string
+	// Check reports every violation of the rule in s to r.
+	Check(s *types.Schema, r *Reporter)
+}
+
+// Reporter collects the problems found by a rule.
+type Reporter struct {
+	rule     string
+	problems []Problem
+}
+
+// Report records a problem with the element at path, which is defined at loc.
+func (r *Reporter) Report(path string, loc errors.Location, format string, a ...interface{}) {
+	r.problems = append(r.problems, Problem{
+		Rule:     r.rule,
+		Path:     path,
+		Message:  fmt.Sprintf(format, a...),
+		Location: loc,
+		Source:   loc.Source,
+	})
+}
+
+type ruleFunc struct {
+	name  string
+	check func(s *types.Schema, r *Reporter)
+}
+
+func (f *ruleFunc) Name() string                       { return f.name }
+func (f *ruleFunc) Check(s *types.Schema, r *Reporter) { f.check(s, r) }
+
+// RuleFunc returns a Rule with the given name that runs check.
+func RuleFunc(name string, check func(s *types.Schema, r *Reporter)) Rule {
+	return &ruleFunc{name: name, check: check}
+}
+
+// Lint runs rules over s and returns the problems ordered by their location. If no rules are
+// given, DefaultRules are used.
+func Lint(s *types.Schema, rules ...Rule) []Problem {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+
+	var problems []Problem
+	for _, rule := range rules {
+		r := &Reporter{rule: rule.Name()}
+		rule.Check(s, r)
+		problems = append(problems, r.problems...)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i].Location, problems[j].Location
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return problems
+}
+
+// DefaultRules returns all built-in rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		TypeNamesPascalCase,
+		FieldNamesCamelCase,
+		EnumValuesUpperCase,
+		DescriptionsRequired,
+		IDFieldsRequireNode,
+		DeprecationReasonRequired,
+		InputSuffix("Input"),
+		RelayConnections,
+	}
+}
+
+var builtinScalars = map[string]bool{
+	"Int":     true,
+	"Float":   true,
+	"String":  true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// userTypes returns the types of s that are not built into every schema, sorted by name.
+func userTypes(s *types.Schema) []types.NamedType {
+	var l []types.NamedType
+	for name, t := range s.Types {
+		if strings.HasPrefix(name, "__") || builtinScalars[name] {
+			continue
+		}
+		l = append(l, t)
+	}
+	sort.Slice(l, func(i, j int) bool { return l[i].TypeName() < l[j].TypeName() })
+	return l
+}
+
+func typeLoc(t types.NamedType) errors.Location {
+	switch t := t.(type) {
+	case *types.ObjectTypeDefinition:
+		return t.Loc
+	case *types.InterfaceTypeDefinition:
+		return t.Loc
+	case *types.Union:
+		return t.Loc
+	case *types.EnumTypeDefinition:
+		return t.Loc
+	case *types.InputObject:
+		return t.Loc
+	case *types.ScalarTypeDefinition:
+		return t.Loc
+	default:
+		return errors.Location{}
+	}
+}
+
+func fields(t types.NamedType) types.FieldsDefinition {
+	switch t := t.(type) {
+	case *types.ObjectTypeDefinition:
+		return t.Fields
+	case *types.InterfaceTypeDefinition:
+		return t.Fields
+	default:
+		return nil
+	}
+}
+
+func unwrapType(t types.Type) types.NamedType {
+	for {
+		switch t2 := t.(type) {
+		case types.NamedType:
+			return t2
+		case *types.List:
+			t = t2.OfType
+		case *types.NonNull:
+			t = t2.OfType
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package lint_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/internal/schema"
+	"github.com/graph-gophers/graphql-go/lint"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+func parse(t *testing.T, sdl string) *types.Schema {
+	t.Helper()
+	s, err := schema.ParseSchema(sdl, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+type problem struct {
+	path string
+	line int
+}
+
+func TestRules(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		rule lint.Rule
+		sdl  string
+		want []problem
+	}{
+		{
+			name: "type names",
+			rule: lint.TypeNamesPascalCase,
+			sdl: `
+				type Query { a: user_profile }
+				type user_profile { a: String }
+				scalar _Any
+			`,
+			want: []problem{{"user_profile", 3}},
+		},
+		{
+			name: "field names",
+			rule: lint.FieldNamesCamelCase,
+			sdl: `
+				type Query {
+					good(first: Int): String
+					Bad(Last_Arg: Int): String
+					_service: String
+				}
+				input In { snake_case: Int }
+			`,
+			want: []problem{{"Query.Bad", 4}, {"Query.Bad(Last_Arg:)", 4}, {"In.snake_case", 7}},
+		},
+		{
+			name: "enum values",
+			rule: lint.EnumValuesUpperCase,
+			sdl: `
+				type Query { a: Status }
+				enum Status {
+					IN_PROGRESS
+					done
+				}
+			`,
+			want: []problem{{"Status.done", 5}},
+		},
+		{
+			name: "descriptions",
+			rule: lint.DescriptionsRequired,
+			sdl: `
+				"The root."
+				type Query {
+					"Documented."
+					a: String
+					b: String
+				}
+			`,
+			want: []problem{{"Query.b", 6}},
+		},
+		{
+			name: "ID requires Node",
+			rule: lint.IDFieldsRequireNode,
+			sdl: `
+				interface Node { id: ID! }
+				type Query { a: A b: B }
+				type A implements Node { id: ID! }
+				type B { id: ID! }
+			`,
+			want: []problem{{"B", 5}},
+		},
+		{
+			name: "deprecation reasons",
+			rule: lint.DeprecationReasonRequired,
+			sdl: `
+				type Query {
+					a: String @deprecated(reason: "Use b.")
+					b: String @deprecated
+					c: String @deprecated(reason: "")
+				}
+				enum E {
+					X @deprecated
+				}
+			`,
+			want: []problem{{"Query.b", 4}, {"Query.c", 5}, {"E.X", 8}},
+		},
+		{
+			name: "input suffix",
+			rule: lint.InputSuffix("Input"),
+			sdl: `
+				type Query { a(x: UserInput, y: Filter): String }
+				input UserInput { a: Int }
+				input Filter { a: Int }
+			`,
+			want: []problem{{"Filter", 4}},
+		},
+		{
+			name: "relay connections",
+			rule: lint.RelayConnections,
+			sdl: `
+				type Query { users: UserConnection posts: PostConnection }
+				type PageInfo { hasNextPage: Boolean! hasPreviousPage: Boolean }
+				type UserConnection { edges: [UserEdge] pageInfo: PageInfo! }
+				type UserEdge { node: User cursor: String! }
+				type User { name: String }
+				type PostConnection { edges: PostEdge pageInfo: PageInfo }
+				type PostEdge { node: String cursor: String }
+			`,
+			want: []problem{{"PageInfo.hasPreviousPage", 3}, {"PostConnection.edges", 7}, {"PostConnection.pageInfo", 7}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lint.Lint(parse(t, tt.sdl), tt.rule)
+			if len(got) != len(tt.want) {
+				t.Fatalf("want %d problems, got %d: %v", len(tt.want), len(got), got)
+			}
+			for i, p := range got {
+				if p.Path != tt.want[i].path || p.Location.Line != tt.want[i].line {
+					t.Errorf("problem %d: want %s at line %d, got %v", i, tt.want[i].path, tt.want[i].line, p)
+				}
+				if p.Rule != tt.rule.Name() {
+					t.Errorf("problem %d: want rule %q, got %q", i, tt.rule.Name(), p.Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestCustomRule(t *testing.T) {
+	noFoo := lint.RuleFunc("no-foo", func(s *types.Schema, r *lint.Reporter) {
+		if _, ok := s.Types["Foo"]; ok {
+			r.Report("Foo", errors.Location{Line: 1, Column: 1}, "type %q is not allowed", "Foo")
+		}
+	})
+	got := lint.Lint(parse(t, `type Query { foo: Foo } type Foo { a: String }`), noFoo)
+	want := lint.Problem{Rule: "no-foo", Path: "Foo", Message: `type "Foo" is not allowed`, Location: errors.Location{Line: 1, Column: 1}}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("want [%v], got %v", want, got)
+	}
+}
+
+func TestProblemJSON(t *testing.T) {
+	rule := lint.RuleFunc("no-foo", func(s *types.Schema, r *lint.Reporter) {
+		r.Report("Foo", errors.Location{Line: 2, Column: 3, Source: "foo.graphql"}, "not allowed")
+	})
+	got := lint.Lint(parse(t, `type Query { a: String }`), rule)
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"rule":"no-foo","path":"Foo","message":"not allowed","location":{"line":2,"column":3},"source":"foo.graphql"}]`
+	if string(b) != want {
+		t.Errorf("want %s, got %s", want, b)
+	}
+}
+
+func TestDefaultRules(t *testing.T) {
+	sdl := `
+		"The root."
+		type Query {
+			"The greeting."
+			hello: String!
+		}
+	`
+	if got := lint.Lint(parse(t, sdl)); len(got) != 0 {
+		t.Errorf("want no problems, got %v", got)
+	}
+}
@@ -0,0 +1,241 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+var (
+	pascalCase = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	camelCase  = regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`)
+	upperCase  = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+// matches reports whether name matches re, ignoring leading underscores which are commonly used
+// for names reserved by tools, e.g. "_service".
+func matches(re *regexp.Regexp, name string) bool {
+	return re.MatchString(strings.TrimLeft(name, "_"))
+}
+
+// TypeNamesPascalCase requires type names to be PascalCase, e.g. "UserProfile".
+var TypeNamesPascalCase = RuleFunc("type-names-pascal-case", func(s *types.Schema, r *Reporter) {
+	for _, t := range userTypes(s) {
+		if !matches(pascalCase, t.TypeName()) {
+			r.Report(t.TypeName(), typeLoc(t), "type name %q should be PascalCase", t.TypeName())
+		}
+	}
+})
+
+// FieldNamesCamelCase requires the names of fields, arguments and input fields to be camelCase,
+// e.g. "createdAt".
+var FieldNamesCamelCase = RuleFunc("field-names-camel-case", func(s *types.Schema, r *Reporter) {
+	checkArgs := func(path string, args types.ArgumentsDefinition, what string) {
+		for _, arg := range args {
+			if !matches(camelCase, arg.Name.Name) {
+				r.Report(path+"("+arg.Name.Name+":)", arg.Loc, "%s name %q should be camelCase", what, arg.Name.Name)
+			}
+		}
+	}
+
+	for _, t := range userTypes(s) {
+		for _, f := range fields(t) {
+			path := t.TypeName() + "." + f.Name
+			if !matches(camelCase, f.Name) {
+				r.Report(path, f.Loc, "field name %q should be camelCase", f.Name)
+			}
+			checkArgs(path, f.Arguments, "argument")
+		}
+		if in, ok := t.(*types.InputObject); ok {
+			for _, v := range in.Values {
+				if !matches(camelCase, v.Name.Name) {
+					r.Report(in.Name+"."+v.Name.Name, v.Loc, "input field name %q should be camelCase", v.Name.Name)
+				}
+			}
+		}
+	}
+})
+
+// EnumValuesUpperCase requires enum values to be UPPER_CASE, e.g. "IN_PROGRESS".
+var EnumValuesUpperCase = RuleFunc("enum-values-upper-case", func(s *types.Schema, r *Reporter) {
+	for _, t := range userTypes(s) {
+		e, ok := t.(*types.EnumTypeDefinition)
+		if !ok {
+			continue
+		}
+		for _, v := range e.EnumValuesDefinition {
+			if !matches(upperCase, v.EnumValue) {
+				r.Report(e.Name+"."+v.EnumValue, v.Loc, "enum value %q should be UPPER_CASE", v.EnumValue)
+			}
+		}
+	}
+})
+
+// DescriptionsRequired requires every type, field and input field to have a description.
+var DescriptionsRequired = RuleFunc("descriptions-required", func(s *types.Schema, r *Reporter) {
+	for _, t := range userTypes(s) {
+		if t.Description() == "" {
+			r.Report(t.TypeName(), typeLoc(t), "type %q has no description", t.TypeName())
+		}
+		for _, f := range fields(t) {
+			if f.Desc == "" {
+				r.Report(t.TypeName()+"."+f.Name, f.Loc, "field %q has no description", t.TypeName()+"."+f.Name)
+			}
+		}
+		if in, ok := t.(*types.InputObject); ok {
+			for _, v := range in.Values {
+				if v.Desc == "" {
+					r.Report(in.Name+"."+v.Name.Name, v.Loc, "input field %q has no description", in.Name+"."+v.Name.Name)
+				}
+			}
+		}
+	}
+})
+
+// IDFieldsRequireNode requires object types with an "id" field of type ID to implement the
+// Node interface, so that they can be refetched by their ID.
+var IDFieldsRequireNode = RuleFunc("id-fields-require-node", func(s *types.Schema, r *Reporter) {
+	for _, t := range userTypes(s) {
+		obj, ok := t.(*types.ObjectTypeDefinition)
+		if !ok {
+			continue
+		}
+		f := obj.Fields.Get("id")
+		if f == nil {
+			continue
+		}
+		if named := unwrapType(f.Type); named == nil || named.TypeName() != "ID" {
+			continue
+		}
+		if !implements(obj, "Node") {
+			r.Report(obj.Name, obj.Loc, "type %q has an ID field but does not implement Node", obj.Name)
+		}
+	}
+})
+
+// DeprecationReasonRequired requires every usage of @deprecated to state a reason.
+var DeprecationReasonRequired = RuleFunc("deprecation-reason-required", func(s *types.Schema, r *Reporter) {
+	var defaultReason types.Value
+	if d := s.Directives["deprecated"]; d != nil {
+		if arg := d.Arguments.Get("reason"); arg != nil {
+			defaultReason = arg.Default
+		}
+	}
+	check := func(path string, directives types.DirectiveList) {
+		d := directives.Get("deprecated")
+		if d == nil {
+			return
+		}
+		// The reason is filled in with the default value of the directive definition if it
+		// is omitted.
+		reason, ok := d.Arguments.Get("reason")
+		if !ok || reason == nil || reason == defaultReason || reason.String() == `""` {
+			r.Report(path, d.Name.Loc, "deprecation of %q has no reason", path)
+		}
+	}
+
+	for _, t := range userTypes(s) {
+		for _, f := range fields(t) {
+			path := t.TypeName() + "." + f.Name
+			check(path, f.Directives)
+			for _, arg := range f.Arguments {
+				check(path+"("+arg.Name.Name+":)", arg.Directives)
+			}
+		}
+		if e, ok := t.(*types.EnumTypeDefinition); ok {
+			for _, v := range e.EnumValuesDefinition {
+				check(e.Name+"."+v.EnumValue, v.Directives)
+			}
+		}
+		if in, ok := t.(*types.InputObject); ok {
+			for _, v := range in.Values {
+				check(in.Name+"."+v.Name.Name, v.Directives)
+			}
+		}
+	}
+})
+
+// InputSuffix returns a rule that requires the names of input object types to end in suffix.
+func InputSuffix(suffix string) Rule {
+	return RuleFunc("input-suffix", func(s *types.Schema, r *Reporter) {
+		for _, t := range userTypes(s) {
+			if in, ok := t.(*types.InputObject); ok && !strings.HasSuffix(in.Name, suffix) {
+				r.Report(in.Name, in.Loc, "input type %q should end in %q", in.Name, suffix)
+			}
+		}
+	})
+}
+
+// RelayConnections requires object types whose name ends in "Connection" to follow the Relay
+// cursor connections specification: an "edges" list of edge types with "node" and "cursor"
+// fields, and a "pageInfo: PageInfo!" field.
+var RelayConnections = RuleFunc("relay-connections", func(s *types.Schema, r *Reporter) {
+	for _, t := range userTypes(s) {
+		obj, ok := t.(*types.ObjectTypeDefinition)
+		if !ok || obj.Name == "Connection" || !strings.HasSuffix(obj.Name, "Connection") {
+			continue
+		}
+
+		if f := obj.Fields.Get("pageInfo"); f == nil {
+			r.Report(obj.Name, obj.Loc, "connection %q has no pageInfo field", obj.Name)
+		} else if f.Type.String() != "PageInfo!" {
+			r.Report(obj.Name+".pageInfo", f.Loc, "field %q must be of type PageInfo!", obj.Name+".pageInfo")
+		}
+
+		edges := obj.Fields.Get("edges")
+		if edges == nil {
+			r.Report(obj.Name, obj.Loc, "connection %q has no edges field", obj.Name)
+			continue
+		}
+		if !isList(edges.Type) {
+			r.Report(obj.Name+".edges", edges.Loc, "field %q must be a list", obj.Name+".edges")
+			continue
+		}
+		edge, ok := unwrapType(edges.Type).(*types.ObjectTypeDefinition)
+		if !ok {
+			r.Report(obj.Name+".edges", edges.Loc, "field %q must be a list of an object type", obj.Name+".edges")
+			continue
+		}
+		if edge.Fields.Get("node") == nil {
+			r.Report(edge.Name, edge.Loc, "edge %q has no node field", edge.Name)
+		}
+		if f := edge.Fields.Get("cursor"); f == nil {
+			r.Report(edge.Name, edge.Loc, "edge %q has no cursor field", edge.Name)
+		} else if _, ok := f.Type.(*types.NonNull); !ok || !isScalar(unwrapType(f.Type)) {
+			r.Report(edge.Name+".cursor", f.Loc, "field %q must be a non-null scalar", edge.Name+".cursor")
+		}
+	}
+
+	if pageInfo, ok := s.Types["PageInfo"].(*types.ObjectTypeDefinition); ok {
+		for _, name := range []string{"hasNextPage", "hasPreviousPage"} {
+			if f := pageInfo.Fields.Get(name); f == nil {
+				r.Report("PageInfo", pageInfo.Loc, "type %q has no %s field", "PageInfo", name)
+			} else if f.Type.String() != "Boolean!" {
+				r.Report("PageInfo."+name, f.Loc, "field %q must be of type Boolean!", "PageInfo."+name)
+			}
+		}
+	}
+})
+
+func implements(obj *types.ObjectTypeDefinition, name string) bool {
+	for _, intf := range obj.Interfaces {
+		if intf.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isList(t types.Type) bool {
+	if nn, ok := t.(*types.NonNull); ok {
+		t = nn.OfType
+	}
+	_, ok := t.(*types.List)
+	return ok
+}
+
+func isScalar(t types.NamedType) bool {
+	_, ok := t.(*types.ScalarTypeDefinition)
+	return ok
+}
@@ -21,3 +21,14 @@ func (l *DefaultLogger) LogPanic(ctx context.Context, value interface{}) {
 	buf = buf[:runtime.Stack(buf, false)]
 	log.Printf("graphql: panic occurred: %v\n%s\ncontext: %v", value, buf, ctx)
 }
+
+// ErrorLogger is implemented by loggers which log the errors hidden from clients by
+// graphql.MaskInternalErrors, together with the correlation ID reported to the client instead.
+type ErrorLogger interface {
+	LogError(ctx context.Context, correlationID string, err error)
+}
+
+// LogError is used to log internal errors which are hidden from clients
+func (l *DefaultLogger) LogError(ctx context.Context, correlationID string, err error) {
+	log.Printf("graphql: internal error %s: %v\ncontext: %v", correlationID, err, ctx)
+}
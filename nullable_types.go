@@ -1,7 +1,9 @@
 package graphql
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 )
 
 // NullString is a string that can be null. Use it in input structs to
@@ -87,6 +89,14 @@ func (s *NullInt) UnmarshalGraphQL(input interface{}) error {
 	case int32:
 		s.Value = &v
 		return nil
+	case json.Number:
+		i, err := strconv.ParseInt(string(v), 10, 32)
+		if err != nil {
+			return fmt.Errorf("wrong type for Int: %s", err)
+		}
+		value := int32(i)
+		s.Value = &value
+		return nil
 	default:
 		return fmt.Errorf("wrong type for Int: %T", v)
 	}
@@ -117,6 +127,13 @@ func (s *NullFloat) UnmarshalGraphQL(input interface{}) error {
 	case float64:
 		s.Value = &v
 		return nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return fmt.Errorf("wrong type for Float: %s", err)
+		}
+		s.Value = &f
+		return nil
 	default:
 		return fmt.Errorf("wrong type for Float: %T", v)
 	}
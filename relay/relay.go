@@ -43,8 +43,16 @@ func UnmarshalSpec(id graphql.ID, v interface{}) error {
 	return json.Unmarshal([]byte(s[i+1:]), v)
 }
 
+// Handler serves GraphQL requests over HTTP.
 type Handler struct {
 	Schema *graphql.Schema
+	// SchemaHolder is used instead of Schema if set, so that the schema can be replaced while
+	// the handler is serving requests.
+	SchemaHolder *graphql.SchemaHolder
+	// UseNumber decodes the numbers in the variables of a request as json.Number instead of
+	// float64, so custom scalars receive them without loss of precision. The UnmarshalGraphQL
+	// methods of the custom scalars and the resolvers receiving maps must handle json.Number then.
+	UseNumber bool
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -53,12 +61,21 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		OperationName string                 `json:"operationName"`
 		Variables     map[string]interface{} `json:"variables"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+	dec := json.NewDecoder(r.Body)
+	if h.UseNumber {
+		dec.UseNumber()
+	}
+	if err := dec.Decode(&params); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	response := h.Schema.Exec(r.Context(), params.Query, params.OperationName, params.Variables)
+	var response *graphql.Response
+	if h.SchemaHolder != nil {
+		response = h.SchemaHolder.Exec(r.Context(), params.Query, params.OperationName, params.Variables)
+	} else {
+		response = h.Schema.Exec(r.Context(), params.Query, params.OperationName, params.Variables)
+	}
 	responseJSON, err := json.Marshal(response)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -1,6 +1,7 @@
 package relay_test
 
 import (
+	"fmt"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -34,3 +35,55 @@ func TestServeHTTP(t *testing.T) {
 		t.Fatalf("Invalid response. Expected [%s], but instead got [%s]", expectedResponse, actualResponse)
 	}
 }
+
+func TestServeHTTPNumberVariables(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/some/path/here", strings.NewReader(`{"query":"query($id: ID!) { human(id: $id) { name } }", "operationName":"", "variables": {"id": 1000}}`))
+	h := relay.Handler{Schema: starwarsSchema, UseNumber: true}
+
+	h.ServeHTTP(w, r)
+
+	expectedResponse := `{"data":{"human":{"name":"Luke Skywalker"}}}`
+	actualResponse := w.Body.String()
+	if expectedResponse != actualResponse {
+		t.Fatalf("Invalid response. Expected [%s], but instead got [%s]", expectedResponse, actualResponse)
+	}
+}
+
+// temperature is a custom scalar which only handles numbers decoded as float64.
+type temperature float64
+
+func (temperature) ImplementsGraphQLType(name string) bool { return name == "Temperature" }
+
+func (t *temperature) UnmarshalGraphQL(input interface{}) error {
+	f, ok := input.(float64)
+	if !ok {
+		return fmt.Errorf("wrong type for Temperature: %T", input)
+	}
+	*t = temperature(f)
+	return nil
+}
+
+type temperatureResolver struct{}
+
+func (*temperatureResolver) Fahrenheit(args struct{ Celsius temperature }) float64 {
+	return float64(args.Celsius)*9/5 + 32
+}
+
+func TestServeHTTPFloatScalarVariables(t *testing.T) {
+	schema := graphql.MustParseSchema(`
+		scalar Temperature
+		type Query { fahrenheit(celsius: Temperature!): Float! }
+	`, &temperatureResolver{})
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/some/path/here", strings.NewReader(`{"query":"query($c: Temperature!) { fahrenheit(celsius: $c) }", "operationName":"", "variables": {"c": 100}}`))
+	h := relay.Handler{Schema: schema}
+
+	h.ServeHTTP(w, r)
+
+	expectedResponse := `{"data":{"fahrenheit":212}}`
+	actualResponse := w.Body.String()
+	if expectedResponse != actualResponse {
+		t.Fatalf("Invalid response. Expected [%s], but instead got [%s]", expectedResponse, actualResponse)
+	}
+}
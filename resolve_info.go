@@ -0,0 +1,43 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go/internal/exec"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// ResolveInfo describes the field being resolved.
+type ResolveInfo struct {
+	// Path is the path of the field in the response, e.g. ["users", 0, "name"].
+	Path []interface{}
+
+	// ParentType is the name of the type the field is selected on.
+	ParentType string
+
+	// Field is the definition of the field in the schema, including its directives.
+	Field *types.FieldDefinition
+
+	// Operation is the operation being executed.
+	Operation *types.OperationDefinition
+
+	// Variables holds the variables of the request.
+	Variables map[string]interface{}
+}
+
+// ResolveInfoFromContext returns information about the field whose resolver received ctx, or nil
+// if ctx is not the context of a resolver. The context passed to Logger.LogPanic for a panic in a
+// resolver carries the information as well.
+func ResolveInfoFromContext(ctx context.Context) *ResolveInfo {
+	info := exec.FieldInfoFromContext(ctx)
+	if info == nil {
+		return nil
+	}
+	return &ResolveInfo{
+		Path:       info.Path(),
+		ParentType: info.Field.TypeName,
+		Field:      &info.Field.FieldDefinition,
+		Operation:  info.Operation,
+		Variables:  info.Vars,
+	}
+}
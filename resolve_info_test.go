@@ -0,0 +1,71 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type resolveInfoQuery struct {
+	infos chan *graphql.ResolveInfo
+}
+
+func (q *resolveInfoQuery) Users() []*resolveInfoUser {
+	return []*resolveInfoUser{{q}, {q}}
+}
+
+type resolveInfoUser struct {
+	q *resolveInfoQuery
+}
+
+func (u *resolveInfoUser) Name(ctx context.Context) string {
+	u.q.infos <- graphql.ResolveInfoFromContext(ctx)
+	return "Ada"
+}
+
+func TestResolveInfoFromContext(t *testing.T) {
+	if info := graphql.ResolveInfoFromContext(context.Background()); info != nil {
+		t.Errorf("want no info outside of resolvers, got %+v", info)
+	}
+
+	q := &resolveInfoQuery{infos: make(chan *graphql.ResolveInfo, 2)}
+	schema := graphql.MustParseSchema(`
+		directive @pii on FIELD_DEFINITION
+		type Query { users: [User!]! }
+		type User { name: String! @pii }
+	`, q)
+
+	resp := schema.Exec(context.Background(), `query Names($all: Boolean!) { people: users @include(if: $all) { name } }`, "", map[string]interface{}{"all": true})
+	if len(resp.Errors) > 0 {
+		t.Fatal(resp.Errors)
+	}
+	close(q.infos)
+
+	var paths [][]interface{}
+	for info := range q.infos {
+		paths = append(paths, info.Path)
+		if info.ParentType != "User" {
+			t.Errorf("unexpected parent type %q", info.ParentType)
+		}
+		if info.Field.Name != "name" || info.Field.Directives.Get("pii") == nil {
+			t.Errorf("unexpected field %+v", info.Field)
+		}
+		if info.Operation.Name.Name != "Names" || info.Operation.Type != "QUERY" {
+			t.Errorf("unexpected operation %+v", info.Operation)
+		}
+		if !reflect.DeepEqual(info.Variables, map[string]interface{}{"all": true}) {
+			t.Errorf("unexpected variables %v", info.Variables)
+		}
+	}
+	if len(paths) != 2 {
+		t.Fatalf("want 2 resolver calls, got %d", len(paths))
+	}
+	if paths[0][1] == 1 {
+		paths[0], paths[1] = paths[1], paths[0]
+	}
+	if want := [][]interface{}{{"people", 0, "name"}, {"people", 1, "name"}}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("got paths %v, want %v", paths, want)
+	}
+}
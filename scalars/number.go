@@ -0,0 +1,195 @@
+package scalars
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+)
+
+// Long is a signed 64-bit integer. It is serialized as a JSON number, so JavaScript clients only
+// read the values between -(2^53) and 2^53 exactly.
+type Long int64
+
+// ImplementsGraphQLType maps Long to the Long scalar.
+func (Long) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+// UnmarshalGraphQL accepts integers and integral floats within the range of an int64.
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case int32:
+		*l = Long(input)
+		return nil
+	case int64:
+		*l = Long(input)
+		return nil
+	case int:
+		*l = Long(input)
+		return nil
+	case float64:
+		if input != math.Trunc(input) || input < math.MinInt64 || input >= math.MaxInt64 {
+			return fmt.Errorf("invalid Long %v", input)
+		}
+		*l = Long(input)
+		return nil
+	case json.Number:
+		return l.UnmarshalGraphQLLiteral("Int", string(input))
+	default:
+		return fmt.Errorf("wrong type for Long: %T", input)
+	}
+}
+
+// UnmarshalGraphQLLiteral accepts Int literals within the range of an int64.
+func (l *Long) UnmarshalGraphQLLiteral(kind string, text string) error {
+	if kind != "Int" {
+		return fmt.Errorf("wrong type for Long: %s", kind)
+	}
+	v, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid Long %s", text)
+	}
+	*l = Long(v)
+	return nil
+}
+
+// MarshalJSON encodes l as a JSON number.
+func (l Long) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(l), 10), nil
+}
+
+// UnmarshalJSON decodes a JSON number.
+func (l *Long) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, l)
+}
+
+// BigInt is an integer of arbitrary size. It is serialized as a JSON string of decimal digits, so
+// that clients do not lose precision. Both integers and strings are accepted as input.
+type BigInt struct {
+	big.Int
+}
+
+// ImplementsGraphQLType maps BigInt to the BigInt scalar.
+func (BigInt) ImplementsGraphQLType(name string) bool {
+	return name == "BigInt"
+}
+
+// UnmarshalGraphQL accepts integers, integral floats and strings of decimal digits.
+func (n *BigInt) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case int32:
+		n.SetInt64(int64(input))
+		return nil
+	case int64:
+		n.SetInt64(input)
+		return nil
+	case int:
+		n.SetInt64(int64(input))
+		return nil
+	case float64:
+		if input != math.Trunc(input) || math.IsInf(input, 0) {
+			return fmt.Errorf("invalid BigInt %v", input)
+		}
+		big.NewFloat(input).Int(&n.Int)
+		return nil
+	case json.Number:
+		return n.UnmarshalGraphQLLiteral("Int", string(input))
+	case string:
+		return n.UnmarshalGraphQLLiteral("String", input)
+	default:
+		return fmt.Errorf("wrong type for BigInt: %T", input)
+	}
+}
+
+// UnmarshalGraphQLLiteral accepts Int literals and strings of decimal digits.
+func (n *BigInt) UnmarshalGraphQLLiteral(kind string, text string) error {
+	if kind != "Int" && kind != "String" {
+		return fmt.Errorf("wrong type for BigInt: %s", kind)
+	}
+	if _, ok := n.SetString(text, 10); !ok {
+		return fmt.Errorf("invalid BigInt %q", text)
+	}
+	return nil
+}
+
+// MarshalJSON encodes n as a JSON string.
+func (n BigInt) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, n.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or string.
+func (n *BigInt) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, n)
+}
+
+// Decimal is a decimal number of arbitrary precision, such as an amount of money, e.g. "12.30".
+// It is serialized as a JSON string as written, so that neither precision nor trailing zeros are
+// lost. Both numbers and numeric strings are accepted as input.
+type Decimal string
+
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// ImplementsGraphQLType maps Decimal to the Decimal scalar.
+func (Decimal) ImplementsGraphQLType(name string) bool {
+	return name == "Decimal"
+}
+
+// UnmarshalGraphQL accepts numbers and strings in decimal notation.
+func (d *Decimal) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case int32:
+		*d = Decimal(strconv.FormatInt(int64(input), 10))
+		return nil
+	case int64:
+		*d = Decimal(strconv.FormatInt(input, 10))
+		return nil
+	case int:
+		*d = Decimal(strconv.Itoa(input))
+		return nil
+	case float64:
+		if math.IsInf(input, 0) || math.IsNaN(input) {
+			return fmt.Errorf("invalid Decimal %v", input)
+		}
+		*d = Decimal(strconv.FormatFloat(input, 'f', -1, 64))
+		return nil
+	case json.Number:
+		return d.UnmarshalGraphQLLiteral("Float", string(input))
+	case string:
+		return d.UnmarshalGraphQLLiteral("String", input)
+	default:
+		return fmt.Errorf("wrong type for Decimal: %T", input)
+	}
+}
+
+// UnmarshalGraphQLLiteral keeps Int, Float and String literals in decimal notation as written.
+func (d *Decimal) UnmarshalGraphQLLiteral(kind string, text string) error {
+	if kind != "Int" && kind != "Float" && kind != "String" {
+		return fmt.Errorf("wrong type for Decimal: %s", kind)
+	}
+	if !decimalPattern.MatchString(text) {
+		return fmt.Errorf("invalid Decimal %q", text)
+	}
+	*d = Decimal(text)
+	return nil
+}
+
+// Rat returns the value of d, or false if d is not in decimal notation.
+func (d Decimal) Rat() (*big.Rat, bool) {
+	if !decimalPattern.MatchString(string(d)) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(string(d))
+}
+
+// MarshalJSON encodes d as a JSON string.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, string(d)), nil
+}
+
+// UnmarshalJSON decodes a JSON number or string.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, d)
+}
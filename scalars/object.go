@@ -0,0 +1,50 @@
+package scalars
+
+import (
+	"fmt"
+)
+
+// Map is an arbitrary JSON object. It resolves both the JSON and the Map scalar, which are
+// aliases. Numbers in a Map are int32, float64 or json.Number values, depending on whether they
+// are written in a query or sent as variables.
+type Map map[string]interface{}
+
+// ImplementsGraphQLType maps Map to the JSON and Map scalars.
+func (Map) ImplementsGraphQLType(name string) bool {
+	return name == "JSON" || name == "Map"
+}
+
+// UnmarshalGraphQL accepts objects.
+func (m *Map) UnmarshalGraphQL(input interface{}) error {
+	v, ok := input.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("wrong type for JSON: %T", input)
+	}
+	*m = v
+	return nil
+}
+
+// Void is the result of a field which only has side effects, such as a mutation. It is always
+// serialized as null and only accepts null as input.
+type Void struct{}
+
+// ImplementsGraphQLType maps Void to the Void scalar.
+func (Void) ImplementsGraphQLType(name string) bool {
+	return name == "Void"
+}
+
+// UnmarshalGraphQL accepts null.
+func (*Void) UnmarshalGraphQL(input interface{}) error {
+	if input != nil {
+		return fmt.Errorf("wrong type for Void: %T", input)
+	}
+	return nil
+}
+
+// Nullable allows Void to be used as the type of an argument or input field.
+func (*Void) Nullable() {}
+
+// MarshalJSON encodes null.
+func (Void) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
@@ -0,0 +1,46 @@
+// Package scalars provides commonly used custom scalars. Each Go type implements
+// decode.Unmarshaler for input and json.Marshaler for output, and each scalar has
+// to be declared in the schema, e.g. by adding the matching SDL constant:
+//
+//	schema := graphql.MustParseSchema(scalars.DateSDL+schemaString, resolver)
+package scalars
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/graph-gophers/graphql-go/decode"
+)
+
+const docURL = "https://pkg.go.dev/github.com/graph-gophers/graphql-go/scalars#"
+
+// The SDL constants declare the scalars of this package in a schema.
+const (
+	LongSDL      = "scalar Long @specifiedBy(url: \"" + docURL + "Long\")\n"
+	BigIntSDL    = "scalar BigInt @specifiedBy(url: \"" + docURL + "BigInt\")\n"
+	DecimalSDL   = "scalar Decimal @specifiedBy(url: \"" + docURL + "Decimal\")\n"
+	DateSDL      = "scalar Date @specifiedBy(url: \"https://tools.ietf.org/html/rfc3339#section-5.6\")\n"
+	LocalTimeSDL = "scalar LocalTime @specifiedBy(url: \"https://tools.ietf.org/html/rfc3339#section-5.6\")\n"
+	DurationSDL  = "scalar Duration @specifiedBy(url: \"https://en.wikipedia.org/wiki/ISO_8601#Durations\")\n"
+	UUIDSDL      = "scalar UUID @specifiedBy(url: \"https://tools.ietf.org/html/rfc4122\")\n"
+	URLSDL       = "scalar URL @specifiedBy(url: \"https://tools.ietf.org/html/rfc3986\")\n"
+	EmailSDL     = "scalar Email @specifiedBy(url: \"https://tools.ietf.org/html/rfc5322#section-3.4.1\")\n"
+	JSONSDL      = "scalar JSON @specifiedBy(url: \"https://tools.ietf.org/html/rfc8259\")\n"
+	MapSDL       = "scalar Map @specifiedBy(url: \"https://tools.ietf.org/html/rfc8259\")\n"
+	VoidSDL      = "scalar Void @specifiedBy(url: \"" + docURL + "Void\")\n"
+
+	// SDL declares all scalars of this package except for Map, which is an alias of JSON.
+	SDL = LongSDL + BigIntSDL + DecimalSDL + DateSDL + LocalTimeSDL + DurationSDL + UUIDSDL + URLSDL + EmailSDL + JSONSDL + VoidSDL
+)
+
+// unmarshalJSON decodes data like the variables of a request and passes the result to u, so
+// that the scalars accept their own JSON encoding.
+func unmarshalJSON(data []byte, u decode.Unmarshaler) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var input interface{}
+	if err := dec.Decode(&input); err != nil {
+		return err
+	}
+	return u.UnmarshalGraphQL(input)
+}
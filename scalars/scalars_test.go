@@ -0,0 +1,203 @@
+package scalars_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/decode"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/graph-gophers/graphql-go/scalars"
+)
+
+type echoResolver struct{}
+
+func (*echoResolver) Long(args struct{ V scalars.Long }) scalars.Long                { return args.V }
+func (*echoResolver) BigInt(args struct{ V scalars.BigInt }) scalars.BigInt          { return args.V }
+func (*echoResolver) Decimal(args struct{ V scalars.Decimal }) scalars.Decimal       { return args.V }
+func (*echoResolver) Date(args struct{ V scalars.Date }) scalars.Date                { return args.V }
+func (*echoResolver) LocalTime(args struct{ V scalars.LocalTime }) scalars.LocalTime { return args.V }
+func (*echoResolver) Duration(args struct{ V scalars.Duration }) scalars.Duration    { return args.V }
+func (*echoResolver) UUID(args struct{ V scalars.UUID }) scalars.UUID                { return args.V }
+func (*echoResolver) URL(args struct{ V scalars.URL }) scalars.URL                   { return args.V }
+func (*echoResolver) Email(args struct{ V scalars.Email }) scalars.Email             { return args.V }
+func (*echoResolver) JSON(args struct{ V scalars.Map }) scalars.Map                  { return args.V }
+func (*echoResolver) Void() *scalars.Void                                            { return nil }
+
+var echoSchema = graphql.MustParseSchema(scalars.SDL+`
+	type Query {
+		long(v: Long!): Long!
+		bigInt(v: BigInt!): BigInt!
+		decimal(v: Decimal!): Decimal!
+		date(v: Date!): Date!
+		localTime(v: LocalTime!): LocalTime!
+		duration(v: Duration!): Duration!
+		uuid(v: UUID!): UUID!
+		url(v: URL!): URL!
+		email(v: Email!): Email!
+		json(v: JSON!): JSON!
+		void: Void
+	}
+`, &echoResolver{})
+
+func TestScalars(t *testing.T) {
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: echoSchema,
+			Query: `
+				{
+					long(v: 9007199254740993)
+					bigInt(v: 123456789012345678901234567890)
+					decimal(v: 0.10000000000000000001)
+					date(v: "2021-02-28")
+					localTime(v: "13:45:00.250")
+					duration(v: "P1DT12H30.5S")
+					uuid(v: "123E4567-E89B-12D3-A456-426614174000")
+					url(v: "https://example.com/a?b=c")
+					email(v: "gopher@example.com")
+					json(v: {a: [1, "b"], c: null})
+					void
+				}
+			`,
+			ExpectedResult: `
+				{
+					"long": 9007199254740993,
+					"bigInt": "123456789012345678901234567890",
+					"decimal": "0.10000000000000000001",
+					"date": "2021-02-28",
+					"localTime": "13:45:00.25",
+					"duration": "PT36H30.5S",
+					"uuid": "123e4567-e89b-12d3-a456-426614174000",
+					"url": "https://example.com/a?b=c",
+					"email": "gopher@example.com",
+					"json": {"a": [1, "b"], "c": null},
+					"void": null
+				}
+			`,
+		},
+		{
+			Schema: echoSchema,
+			Query: `
+				query($long: Long!, $bigInt: BigInt!, $decimal: Decimal!, $duration: Duration!) {
+					long(v: $long)
+					bigInt(v: $bigInt)
+					decimal(v: $decimal)
+					duration(v: $duration)
+				}
+			`,
+			Variables: map[string]interface{}{
+				"long":     json.Number("-9223372036854775808"),
+				"bigInt":   "-1000000000000000000000",
+				"decimal":  json.Number("12.30"),
+				"duration": "-PT0.000000001S",
+			},
+			ExpectedResult: `
+				{
+					"long": -9223372036854775808,
+					"bigInt": "-1000000000000000000000",
+					"decimal": "12.30",
+					"duration": "-PT0.000000001S"
+				}
+			`,
+		},
+		{
+			Schema: echoSchema,
+			Query: `
+				query($bigInt: BigInt!) {
+					bigInt(v: $bigInt)
+				}
+			`,
+			Variables: map[string]interface{}{
+				"bigInt": 1e20,
+			},
+			ExpectedResult: `
+				{
+					"bigInt": "100000000000000000000"
+				}
+			`,
+		},
+		{
+			Schema: echoSchema,
+			Query: `
+				{
+					date(v: {year: 2021})
+				}
+			`,
+			ExpectedErrors: []*errors.QueryError{{
+				Message:   "Argument \"v\" has invalid value {year: 2021}.\nExpected type \"Date\", found {year: 2021}; wrong type for Date: map[string]interface {}",
+				Locations: []errors.Location{{Line: 3, Column: 14}},
+				Rule:      "ArgumentsOfCorrectType",
+			}},
+		},
+		{
+			Schema: echoSchema,
+			Query: `
+				{
+					__type(name: "Date") {
+						specifiedByURL
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"__type": {
+						"specifiedByURL": "https://tools.ietf.org/html/rfc3339#section-5.6"
+					}
+				}
+			`,
+		},
+	})
+}
+
+func TestInvalidInput(t *testing.T) {
+	for _, tt := range []struct {
+		scalar decode.Unmarshaler
+		input  interface{}
+		err    string
+	}{
+		{new(scalars.Long), json.Number("9223372036854775808"), "invalid Long 9223372036854775808"},
+		{new(scalars.Long), 1.5, "invalid Long 1.5"},
+		{new(scalars.BigInt), "12a", `invalid BigInt "12a"`},
+		{new(scalars.BigInt), 1.5, "invalid BigInt 1.5"},
+		{new(scalars.Decimal), "1/3", `invalid Decimal "1/3"`},
+		{new(scalars.Date), "2021-02-29", `invalid Date "2021-02-29"`},
+		{new(scalars.LocalTime), "24:00:00", `invalid LocalTime "24:00:00"`},
+		{new(scalars.Duration), "P1Y", `invalid Duration "P1Y"`},
+		{new(scalars.Duration), "PT", `invalid Duration "PT"`},
+		{new(scalars.Duration), "PT9999999999H", `invalid Duration "PT9999999999H": out of range`},
+		{new(scalars.UUID), "123e4567e89b12d3a456426614174000", `invalid UUID "123e4567e89b12d3a456426614174000"`},
+		{new(scalars.URL), "/relative", `invalid URL "/relative"`},
+		{new(scalars.Email), "Gopher <gopher@example.com>", `invalid Email "Gopher <gopher@example.com>"`},
+		{new(scalars.Map), []interface{}{}, "wrong type for JSON: []interface {}"},
+		{new(scalars.Void), int32(1), "wrong type for Void: int32"},
+	} {
+		err := tt.scalar.UnmarshalGraphQL(tt.input)
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("%T: want error %q for %#v, got %v", tt.scalar, tt.err, tt.input, err)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		Long     scalars.Long
+		Duration scalars.Duration
+		Date     scalars.Date
+	}
+	in := `{"Long":9007199254740993,"Duration":"PT1H1M1S","Date":"2006-01-02"}`
+	if err := json.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Long != 9007199254740993 || v.Duration.Duration != time.Hour+time.Minute+time.Second {
+		t.Errorf("unexpected values %+v", v)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("want %s, got %s", in, out)
+	}
+}
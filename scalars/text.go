@@ -0,0 +1,110 @@
+package scalars
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+)
+
+// UUID is a universally unique identifier, serialized in its canonical lowercase form such as
+// "123e4567-e89b-12d3-a456-426614174000".
+type UUID [16]byte
+
+// ImplementsGraphQLType maps UUID to the UUID scalar.
+func (UUID) ImplementsGraphQLType(name string) bool {
+	return name == "UUID"
+}
+
+// UnmarshalGraphQL accepts UUIDs in their canonical form in upper or lower case.
+func (u *UUID) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("wrong type for UUID: %T", input)
+	}
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return fmt.Errorf("invalid UUID %q", s)
+	}
+	digits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+		return fmt.Errorf("invalid UUID %q", s)
+	}
+	return nil
+}
+
+func (u UUID) String() string {
+	s := hex.EncodeToString(u[:])
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32]
+}
+
+// MarshalJSON encodes u in its canonical form.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, u.String()), nil
+}
+
+// UnmarshalJSON decodes a UUID in its canonical form.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, u)
+}
+
+// URL is an absolute URL, i.e. one with a scheme.
+type URL struct {
+	url.URL
+}
+
+// ImplementsGraphQLType maps URL to the URL scalar.
+func (URL) ImplementsGraphQLType(name string) bool {
+	return name == "URL"
+}
+
+// UnmarshalGraphQL accepts absolute URLs.
+func (u *URL) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("wrong type for URL: %T", input)
+	}
+	v, err := url.Parse(s)
+	if err != nil || !v.IsAbs() {
+		return fmt.Errorf("invalid URL %q", s)
+	}
+	u.URL = *v
+	return nil
+}
+
+// MarshalJSON encodes u as a JSON string.
+func (u URL) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, u.String()), nil
+}
+
+// UnmarshalJSON decodes an absolute URL.
+func (u *URL) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, u)
+}
+
+// Email is an email address without a display name, such as "gopher@example.com".
+type Email string
+
+// ImplementsGraphQLType maps Email to the Email scalar.
+func (Email) ImplementsGraphQLType(name string) bool {
+	return name == "Email"
+}
+
+// UnmarshalGraphQL accepts RFC 5322 addresses without a display name or angle brackets.
+func (e *Email) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("wrong type for Email: %T", input)
+	}
+	a, err := mail.ParseAddress(s)
+	if err != nil || a.Name != "" || a.Address != s {
+		return fmt.Errorf("invalid Email %q", s)
+	}
+	*e = Email(s)
+	return nil
+}
+
+// UnmarshalJSON decodes an email address.
+func (e *Email) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, e)
+}
@@ -0,0 +1,177 @@
+package scalars
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout      = "2006-01-02"
+	localTimeLayout = "15:04:05.999999999"
+)
+
+// Date is a calendar date without a time zone, serialized as "2006-01-02".
+type Date struct {
+	time.Time
+}
+
+// ImplementsGraphQLType maps Date to the Date scalar.
+func (Date) ImplementsGraphQLType(name string) bool {
+	return name == "Date"
+}
+
+// UnmarshalGraphQL accepts RFC 3339 full dates such as "2006-01-02".
+func (d *Date) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("wrong type for Date: %T", input)
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return fmt.Errorf("invalid Date %q", s)
+	}
+	d.Time = t
+	return nil
+}
+
+// MarshalJSON encodes the date of d in its location.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, d.Format(dateLayout)), nil
+}
+
+// UnmarshalJSON decodes a date in UTC.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, d)
+}
+
+// LocalTime is a time of day without a date and time zone, serialized as "15:04:05" with
+// optional fractional seconds.
+type LocalTime struct {
+	time.Time
+}
+
+// ImplementsGraphQLType maps LocalTime to the LocalTime scalar.
+func (LocalTime) ImplementsGraphQLType(name string) bool {
+	return name == "LocalTime"
+}
+
+// UnmarshalGraphQL accepts RFC 3339 partial times such as "15:04:05" or "15:04:05.123". The date
+// of the result is January 1, year 0, UTC.
+func (t *LocalTime) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("wrong type for LocalTime: %T", input)
+	}
+	v, err := time.Parse(localTimeLayout, s)
+	if err != nil {
+		return fmt.Errorf("invalid LocalTime %q", s)
+	}
+	t.Time = v
+	return nil
+}
+
+// MarshalJSON encodes the clock of t in its location.
+func (t LocalTime) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, t.Format(localTimeLayout)), nil
+}
+
+// UnmarshalJSON decodes a time of day.
+func (t *LocalTime) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, t)
+}
+
+// Duration is a time.Duration serialized in the ISO 8601 format, e.g. "PT1H30M". Durations in
+// years or months are not supported because their length is not fixed, and a day is 24 hours.
+type Duration struct {
+	time.Duration
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+var durationUnits = []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+// ImplementsGraphQLType maps Duration to the Duration scalar.
+func (Duration) ImplementsGraphQLType(name string) bool {
+	return name == "Duration"
+}
+
+// UnmarshalGraphQL accepts ISO 8601 durations in weeks, days, hours, minutes and seconds, such as
+// "P1DT12H" or "-PT0.5S".
+func (d *Duration) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("wrong type for Duration: %T", input)
+	}
+	m := durationPattern.FindStringSubmatch(s)
+	if m == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return fmt.Errorf("invalid Duration %q", s)
+	}
+	var total time.Duration
+	for i, unit := range durationUnits {
+		if m[i+2] == "" {
+			continue
+		}
+		v, ok := durationComponent(m[i+2], unit)
+		if !ok || v > time.Duration(1<<63-1)-total {
+			return fmt.Errorf("invalid Duration %q: out of range", s)
+		}
+		total += v
+	}
+	if m[1] == "-" {
+		total = -total
+	}
+	d.Duration = total
+	return nil
+}
+
+// durationComponent returns the duration of a number of units, which may have a fraction.
+func durationComponent(s string, unit time.Duration) (time.Duration, bool) {
+	s = strings.Replace(s, ",", ".", 1)
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+	n, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || n > int64(time.Duration(1<<63-1)/unit) {
+		return 0, false
+	}
+	d := time.Duration(n) * unit
+	for _, c := range frac {
+		unit /= 10
+		d += time.Duration(c-'0') * unit
+	}
+	return d, true
+}
+
+// MarshalJSON encodes d in hours, minutes and seconds, e.g. "PT36H0.5S".
+func (d Duration) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	u := uint64(d.Duration)
+	if d.Duration < 0 {
+		b.WriteByte('-')
+		u = -u
+	}
+	b.WriteString("PT")
+	if u == 0 {
+		b.WriteString("0S")
+	}
+	if h := u / uint64(time.Hour); h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+	}
+	if m := u % uint64(time.Hour) / uint64(time.Minute); m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+	}
+	if ns := u % uint64(time.Minute); ns > 0 {
+		s := fmt.Sprintf("%d.%09d", ns/uint64(time.Second), ns%uint64(time.Second))
+		fmt.Fprintf(&b, "%sS", strings.TrimRight(strings.TrimRight(s, "0"), "."))
+	}
+	return strconv.AppendQuote(nil, b.String()), nil
+}
+
+// UnmarshalJSON decodes an ISO 8601 duration.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, d)
+}
@@ -0,0 +1,202 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+// SchemaHolder holds the schema of a server and allows replacing it while the server is
+// running, e.g. to reload parts of the schema from configuration. It is safe for concurrent use.
+//
+// Requests are executed with the schema that is current when they start. Requests executing
+// when the schema is replaced finish on the previous schema, and subscriptions keep running on
+// it until they end or are drained with SwapAndDrain.
+type SchemaHolder struct {
+	mu      sync.RWMutex
+	current *schemaGeneration
+	retired []*schemaGeneration
+}
+
+// NewSchemaHolder returns a SchemaHolder with the initial schema s.
+func NewSchemaHolder(s *Schema) *SchemaHolder {
+	return &SchemaHolder{current: newSchemaGeneration(s)}
+}
+
+// Schema returns the current schema.
+func (h *SchemaHolder) Schema() *Schema {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.current.schema
+}
+
+// Exec executes the query with the current schema. See Schema.Exec.
+func (h *SchemaHolder) Exec(ctx context.Context, queryString string, operationName string, variables map[string]interface{}) *Response {
+	g := h.begin()
+	defer g.end()
+	return g.schema.Exec(ctx, queryString, operationName, variables)
+}
+
+// Subscribe starts the subscription with the current schema. See Schema.Subscribe.
+func (h *SchemaHolder) Subscribe(ctx context.Context, queryString string, operationName string, variables map[string]interface{}) (<-chan interface{}, error) {
+	g := h.begin()
+	ctx, cancel := context.WithCancel(ctx)
+	id := g.addSubscription(cancel)
+	done := func() {
+		cancel()
+		g.removeSubscription(id)
+		g.end()
+	}
+
+	responses, err := g.schema.Subscribe(ctx, queryString, operationName, variables)
+	if err != nil {
+		done()
+		return nil, err
+	}
+
+	c := make(chan interface{})
+	go func() {
+		defer close(c)
+		defer done()
+		for resp := range responses {
+			select {
+			case c <- resp:
+			case <-ctx.Done():
+				// The subscription closes its channel once it noticed the cancellation.
+				for range responses {
+				}
+				return
+			}
+		}
+	}()
+	return c, nil
+}
+
+// Swap replaces the current schema with s. It does not wait for requests executing on the
+// previous schema.
+func (h *SchemaHolder) Swap(s *Schema) {
+	h.mu.Lock()
+	old := h.current
+	h.current = newSchemaGeneration(s)
+	retired := h.retired[:0]
+	for _, g := range h.retired {
+		if !g.isIdle() {
+			retired = append(retired, g)
+		}
+	}
+	h.retired = append(retired, old)
+	h.mu.Unlock()
+
+	old.retire()
+}
+
+// SwapAndDrain replaces the current schema with s, cancels the subscriptions running on
+// previous schemas and waits until all requests executing on previous schemas have finished.
+// It returns the error of ctx if ctx is done before.
+func (h *SchemaHolder) SwapAndDrain(ctx context.Context, s *Schema) error {
+	h.Swap(s)
+
+	h.mu.Lock()
+	retired := h.retired
+	h.retired = nil
+	h.mu.Unlock()
+
+	for _, g := range retired {
+		g.cancelSubscriptions()
+	}
+	for i, g := range retired {
+		select {
+		case <-g.idle:
+		case <-ctx.Done():
+			// Keep the generations that did not finish, so that a later drain waits for them.
+			h.mu.Lock()
+			h.retired = append(h.retired, retired[i:]...)
+			h.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (h *SchemaHolder) begin() *schemaGeneration {
+	// The read lock guarantees that no request begins on a generation after it was retired.
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	h.current.begin()
+	return h.current
+}
+
+// schemaGeneration tracks the requests executing on one schema of a SchemaHolder.
+type schemaGeneration struct {
+	schema *Schema
+
+	mu            sync.Mutex
+	active        int
+	retired       bool
+	idle          chan struct{} // closed once retired and no request is active
+	subscriptions map[int]context.CancelFunc
+	nextID        int
+}
+
+func newSchemaGeneration(s *Schema) *schemaGeneration {
+	return &schemaGeneration{
+		schema:        s,
+		idle:          make(chan struct{}),
+		subscriptions: make(map[int]context.CancelFunc),
+	}
+}
+
+func (g *schemaGeneration) begin() {
+	g.mu.Lock()
+	g.active++
+	g.mu.Unlock()
+}
+
+func (g *schemaGeneration) end() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.active--
+	if g.retired && g.active == 0 {
+		close(g.idle)
+	}
+}
+
+func (g *schemaGeneration) retire() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.retired = true
+	if g.active == 0 {
+		close(g.idle)
+	}
+}
+
+func (g *schemaGeneration) isIdle() bool {
+	select {
+	case <-g.idle:
+		return true
+	default:
+		return false
+	}
+}
+
+func (g *schemaGeneration) addSubscription(cancel context.CancelFunc) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	id := g.nextID
+	g.nextID++
+	g.subscriptions[id] = cancel
+	return id
+}
+
+func (g *schemaGeneration) removeSubscription(id int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.subscriptions, id)
+}
+
+func (g *schemaGeneration) cancelSubscriptions() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, cancel := range g.subscriptions {
+		cancel()
+	}
+}
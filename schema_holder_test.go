@@ -0,0 +1,134 @@
+package graphql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type holderQuery struct {
+	version string
+	started chan struct{}
+	release chan struct{}
+}
+
+func (q *holderQuery) Version() string {
+	if q.started != nil {
+		close(q.started)
+		<-q.release
+	}
+	return q.version
+}
+
+type holderSubscription struct{}
+
+func (*holderSubscription) Version() string { return "v1" }
+
+func (*holderSubscription) Ticks(ctx context.Context) <-chan int32 {
+	c := make(chan int32)
+	go func() {
+		defer close(c)
+		for i := int32(0); ; i++ {
+			select {
+			case c <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
+
+func TestSchemaHolder(t *testing.T) {
+	const schema = `type Query { version: String! }`
+	v1 := &holderQuery{version: "v1", started: make(chan struct{}), release: make(chan struct{})}
+	h := graphql.NewSchemaHolder(graphql.MustParseSchema(schema, v1))
+
+	inFlight := make(chan *graphql.Response)
+	go func() {
+		inFlight <- h.Exec(context.Background(), `{ version }`, "", nil)
+	}()
+	<-v1.started
+
+	h.Swap(graphql.MustParseSchema(schema, &holderQuery{version: "v2"}))
+	if resp := h.Exec(context.Background(), `{ version }`, "", nil); string(resp.Data) != `{"version":"v2"}` {
+		t.Errorf("want new schema after swap, got %s", resp.Data)
+	}
+
+	drained := make(chan error)
+	go func() {
+		drained <- h.SwapAndDrain(context.Background(), graphql.MustParseSchema(schema, &holderQuery{version: "v3"}))
+	}()
+	select {
+	case <-drained:
+		t.Fatal("drain must wait for requests executing on previous schemas")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(v1.release)
+	if resp := <-inFlight; string(resp.Data) != `{"version":"v1"}` {
+		t.Errorf("want in-flight request to finish on the old schema, got %s", resp.Data)
+	}
+	if err := <-drained; err != nil {
+		t.Fatal(err)
+	}
+	if resp := h.Exec(context.Background(), `{ version }`, "", nil); string(resp.Data) != `{"version":"v3"}` {
+		t.Errorf("want latest schema, got %s", resp.Data)
+	}
+}
+
+func TestSchemaHolder_drainSubscriptions(t *testing.T) {
+	const schema = `
+		type Query { version: String! }
+		type Subscription { ticks: Int! }
+	`
+	h := graphql.NewSchemaHolder(graphql.MustParseSchema(schema, &holderSubscription{}))
+
+	c, err := h.Subscribe(context.Background(), `subscription { ticks }`, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp := (<-c).(*graphql.Response); string(resp.Data) != `{"ticks":0}` {
+		t.Fatalf("unexpected response %s", resp.Data)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	drained := make(chan error)
+	go func() {
+		drained <- h.SwapAndDrain(ctx, graphql.MustParseSchema(schema, &holderSubscription{}))
+	}()
+
+	for range c {
+	}
+	if err := <-drained; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSchemaHolder_drainTimeout(t *testing.T) {
+	const schema = `type Query { version: String! }`
+	v1 := &holderQuery{version: "v1", started: make(chan struct{}), release: make(chan struct{})}
+	h := graphql.NewSchemaHolder(graphql.MustParseSchema(schema, v1))
+
+	done := make(chan struct{})
+	go func() {
+		h.Exec(context.Background(), `{ version }`, "", nil)
+		close(done)
+	}()
+	<-v1.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := h.SwapAndDrain(ctx, graphql.MustParseSchema(schema, &holderQuery{version: "v2"})); err != context.DeadlineExceeded {
+		t.Errorf("want %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	close(v1.release)
+	<-done
+	if err := h.SwapAndDrain(context.Background(), graphql.MustParseSchema(schema, &holderQuery{version: "v3"})); err != nil {
+		t.Fatal(err)
+	}
+}
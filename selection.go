@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go/internal/exec"
+	"github.com/graph-gophers/graphql-go/internal/exec/resolvable"
+	"github.com/graph-gophers/graphql-go/internal/exec/selected"
+)
+
+// SelectedField is a field selected by the client beneath the field being resolved.
+type SelectedField struct {
+	// Name is the name of the field in the schema.
+	Name string
+
+	// Alias is the key of the field in the response, which is Name if the field has no alias.
+	Alias string
+
+	// Args holds the arguments of the field given in the query, if the field has arguments.
+	Args map[string]interface{}
+
+	// TypeCondition is the object type the field is selected on, if it is selected by a
+	// fragment on a possible type of an interface or union.
+	TypeCondition string
+
+	// SelectedFields are the fields selected beneath this field.
+	SelectedFields []SelectedField
+}
+
+// SelectedFields returns the fields selected beneath the field whose resolver received ctx, in
+// the order of the query. Fragments are expanded, fields skipped by @skip or @include are
+// omitted and fields selected several times under the same alias are merged. It returns nil if
+// ctx is not the context of a resolver or the field has no selection set.
+func SelectedFields(ctx context.Context) []SelectedField {
+	info := exec.FieldInfoFromContext(ctx)
+	if info == nil {
+		return nil
+	}
+	return selectedFields(info.Sels)
+}
+
+func selectedFields(sels []selected.Selection) []SelectedField {
+	type key struct{ typeCondition, alias string }
+	var fields []SelectedField
+	var subSels [][]selected.Selection
+	index := make(map[key]int)
+
+	add := func(f SelectedField, sels []selected.Selection) {
+		k := key{f.TypeCondition, f.Alias}
+		i, ok := index[k]
+		if !ok {
+			i = len(fields)
+			index[k] = i
+			fields = append(fields, f)
+			subSels = append(subSels, nil)
+		}
+		subSels[i] = append(subSels[i], sels...)
+	}
+
+	var collect func(sels []selected.Selection, typeCondition string)
+	collect = func(sels []selected.Selection, typeCondition string) {
+		for _, sel := range sels {
+			switch sel := sel.(type) {
+			case *selected.SchemaField:
+				add(SelectedField{Name: sel.Name, Alias: sel.Alias, Args: sel.Args, TypeCondition: typeCondition}, sel.Sels)
+			case *selected.TypenameField:
+				add(SelectedField{Name: "__typename", Alias: sel.Alias, TypeCondition: typeCondition}, nil)
+			case *selected.TypeAssertion:
+				collect(sel.Sels, sel.TypeExec.(*resolvable.Object).Name)
+			}
+		}
+	}
+	collect(sels, "")
+
+	for i := range fields {
+		fields[i].SelectedFields = selectedFields(subSels[i])
+	}
+	return fields
+}
@@ -0,0 +1,81 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type selectionQuery struct {
+	selected []graphql.SelectedField
+}
+
+func (q *selectionQuery) Users(ctx context.Context) []*selectionUser {
+	q.selected = graphql.SelectedFields(ctx)
+	return nil
+}
+
+type selectionUser struct{}
+
+func (*selectionUser) ID() graphql.ID                                      { return "" }
+func (*selectionUser) Name() string                                        { return "" }
+func (*selectionUser) Email() string                                       { return "" }
+func (*selectionUser) Friends(args struct{ First int32 }) []*selectionUser { return nil }
+func (*selectionUser) Pet() selectionPet                                   { return nil }
+
+type selectionPet interface{}
+
+type selectionDog struct{}
+
+func (*selectionDog) Barks() bool { return true }
+
+func TestSelectedFields(t *testing.T) {
+	q := &selectionQuery{}
+	schema := graphql.MustParseSchema(`
+		type Query { users: [User!]! }
+		type User {
+			id: ID!
+			name: String!
+			email: String!
+			friends(first: Int!): [User!]!
+			pet: Pet
+		}
+		type Dog { barks: Boolean! }
+		union Pet = Dog
+	`, q, graphql.ResolverType("Dog", (*selectionDog)(nil)))
+
+	resp := schema.Exec(context.Background(), `
+		query($skip: Boolean!) {
+			users {
+				id
+				...userFields
+				email @skip(if: $skip)
+				buddies: friends(first: 3) { id }
+				buddies: friends(first: 3) { name }
+				pet { __typename ... on Dog { barks } }
+			}
+		}
+		fragment userFields on User { name id }
+	`, "", map[string]interface{}{"skip": true})
+	if len(resp.Errors) > 0 {
+		t.Fatal(resp.Errors)
+	}
+
+	want := []graphql.SelectedField{
+		{Name: "id", Alias: "id"},
+		{Name: "name", Alias: "name"},
+		{Name: "friends", Alias: "buddies", Args: map[string]interface{}{"first": int32(3)}, SelectedFields: []graphql.SelectedField{
+			{Name: "id", Alias: "id"},
+			{Name: "name", Alias: "name"},
+		}},
+		{Name: "pet", Alias: "pet", SelectedFields: []graphql.SelectedField{
+			{Name: "__typename", Alias: "__typename"},
+			{Name: "barks", Alias: "barks", TypeCondition: "Dog"},
+		}},
+	}
+	if !reflect.DeepEqual(q.selected, want) {
+		t.Errorf("got %+v, want %+v", q.selected, want)
+	}
+}
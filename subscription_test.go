@@ -153,7 +153,7 @@ func TestSchemaSubscribe(t *testing.T) {
 					Data: json.RawMessage(`
 						null
 					`),
-					Errors: []*qerrors.QueryError{qerrors.Errorf("%s", resolverErr)},
+					Errors: []*qerrors.QueryError{{Message: resolverErr.Error(), Locations: []qerrors.Location{{Line: 4, Column: 7}}}},
 				},
 				{
 					Data: json.RawMessage(`
@@ -211,7 +211,7 @@ func TestSchemaSubscribe(t *testing.T) {
 					Data: json.RawMessage(`
 						null
 					`),
-					Errors: []*qerrors.QueryError{qerrors.Errorf("%s", resolverErr)},
+					Errors: []*qerrors.QueryError{{Message: resolverErr.Error(), Locations: []qerrors.Location{{Line: 3, Column: 6}}}},
 				},
 			},
 		},
@@ -240,7 +240,7 @@ func TestSchemaSubscribe(t *testing.T) {
 							}
 						}
 					`),
-					Errors: []*qerrors.QueryError{qerrors.Errorf("%s", resolverErr)},
+					Errors: []*qerrors.QueryError{{Message: resolverErr.Error(), Locations: []qerrors.Location{{Line: 4, Column: 7}}}},
 				},
 			},
 		},
@@ -263,7 +263,7 @@ func TestSchemaSubscribe(t *testing.T) {
 							"helloSaidNullable": null
 						}
 					`),
-					Errors: []*qerrors.QueryError{qerrors.Errorf("%s", resolverErr)},
+					Errors: []*qerrors.QueryError{{Message: resolverErr.Error(), Locations: []qerrors.Location{{Line: 3, Column: 6}}}},
 				},
 			},
 		},
@@ -340,7 +340,7 @@ func TestRootOperations_invalidSubscriptionSchema(t *testing.T) {
 					}
 				`,
 			},
-			Want: want{Error: `graphql: type "Subscription" not found`},
+			Want: want{Error: `graphql: type "Subscription" not found (line 4, column 21)`},
 		},
 	}
 
@@ -27,7 +27,7 @@ func (s *Schema) Subscribe(ctx context.Context, queryString string, operationNam
 	if _, ok := s.schema.EntryPoints["subscription"]; !ok {
 		return nil, errors.New("no subscriptions are offered by the schema")
 	}
-	return s.subscribe(ctx, queryString, operationName, variables, s.res), nil
+	return s.presentResponses(ctx, s.subscribe(ctx, queryString, operationName, variables, s.res)), nil
 }
 
 func (s *Schema) subscribe(ctx context.Context, queryString string, operationName string, variables map[string]interface{}, res *resolvable.Schema) <-chan interface{} {
@@ -36,8 +36,9 @@ func (s *Schema) subscribe(ctx context.Context, queryString string, operationNam
 		return sendAndReturnClosed(&Response{Errors: []*qerrors.QueryError{qErr}})
 	}
 
+	typeSystem := s.schemaFor(ctx)
 	validationFinish := s.validationTracer.TraceValidation(ctx)
-	errs := validation.Validate(s.schema, doc, variables, s.maxDepth)
+	coerced, errs := validation.Validate(typeSystem, doc, variables, s.maxDepth, res.UnmarshalScalar)
 	validationFinish(errs)
 	if len(errs) != 0 {
 		return sendAndReturnClosed(&Response{Errors: errs})
@@ -48,20 +49,23 @@ func (s *Schema) subscribe(ctx context.Context, queryString string, operationNam
 		return sendAndReturnClosed(&Response{Errors: []*qerrors.QueryError{qerrors.Errorf("%s", err)}})
 	}
 
+	variables = coerced[op]
+
 	r := &exec.Request{
 		Request: selected.Request{
 			Doc:    doc,
 			Vars:   variables,
-			Schema: s.schema,
+			Schema: typeSystem,
 		},
 		Limiter:                  make(chan struct{}, s.maxParallelism),
 		Tracer:                   s.tracer,
 		Logger:                   s.logger,
 		SubscribeResolverTimeout: s.subscribeResolverTimeout,
+		InternalError:            s.internalErrorFunc(),
 	}
 	varTypes := make(map[string]*introspection.Type)
 	for _, v := range op.Vars {
-		t, err := common.ResolveType(v.Type, s.schema.Resolve)
+		t, err := common.ResolveType(v.Type, typeSystem.Resolve)
 		if err != nil {
 			return sendAndReturnClosed(&Response{Errors: []*qerrors.QueryError{err}})
 		}
@@ -70,19 +74,24 @@ func (s *Schema) subscribe(ctx context.Context, queryString string, operationNam
 
 	if op.Type == query.Query || op.Type == query.Mutation {
 		data, errs := r.Execute(ctx, res, op)
-		return sendAndReturnClosed(&Response{Data: data, Errors: errs})
+		return sendAndReturnClosed(&Response{Data: data, Errors: errs, Extensions: warningsExtensions(r.Warnings)})
 	}
 
 	responses := r.Subscribe(ctx, res, op)
 	c := make(chan interface{})
 	go func() {
+		defer close(c)
 		for resp := range responses {
-			c <- &Response{
-				Data:   resp.Data,
-				Errors: resp.Errors,
+			select {
+			case c <- &Response{
+				Data:       resp.Data,
+				Errors:     resp.Errors,
+				Extensions: warningsExtensions(resp.Warnings),
+			}:
+			case <-ctx.Done():
+				return
 			}
 		}
-		close(c)
 	}()
 
 	return c
@@ -44,6 +44,13 @@ func (t *Time) UnmarshalGraphQL(input interface{}) error {
 	case float64:
 		t.Time = time.Unix(int64(input), 0)
 		return nil
+	case json.Number:
+		sec, err := input.Int64()
+		if err != nil {
+			return fmt.Errorf("wrong type for Time: %s", err)
+		}
+		t.Time = time.Unix(sec, 0)
+		return nil
 	default:
 		return fmt.Errorf("wrong type for Time: %T", input)
 	}
@@ -1,5 +1,7 @@
 package types
 
+import "github.com/graph-gophers/graphql-go/errors"
+
 // ObjectTypeDefinition represents a GraphQL ObjectTypeDefinition.
 //
 // type FooObject {
@@ -15,6 +17,7 @@ type ObjectTypeDefinition struct {
 	Directives DirectiveList
 
 	InterfaceNames []string
+	Loc            errors.Location
 }
 
 func (*ObjectTypeDefinition) Kind() string          { return "OBJECT" }
@@ -1,5 +1,7 @@
 package types
 
+import "github.com/graph-gophers/graphql-go/errors"
+
 // Schema represents a GraphQL service's collective type system capabilities.
 // A schema is defined in terms of the types and directives it supports as well as the root
 // operation types for each kind of operation: `query`, `mutation`, and `subscription`.
@@ -31,10 +33,12 @@ type Schema struct {
 	UseFieldResolvers bool
 
 	EntryPointNames map[string]string
-	Objects         []*ObjectTypeDefinition
-	Unions          []*Union
-	Enums           []*EnumTypeDefinition
-	Extensions      []*Extension
+	// EntryPointLocs holds the locations of the root operation types named in a schema definition.
+	EntryPointLocs map[string]errors.Location
+	Objects        []*ObjectTypeDefinition
+	Unions         []*Union
+	Enums          []*EnumTypeDefinition
+	Extensions     []*Extension
 }
 
 func (s *Schema) Resolve(name string) Type {
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"strconv"
 	"strings"
 	"text/scanner"
@@ -31,18 +32,18 @@ type PrimitiveValue struct {
 func (val *PrimitiveValue) Deserialize(vars map[string]interface{}) interface{} {
 	switch val.Type {
 	case scanner.Int:
-		value, err := strconv.ParseInt(val.Text, 10, 32)
-		if err != nil {
-			panic(err)
+		// Int literals out of the range of Int are only valid for Float and custom scalars,
+		// so keep as much of their value as possible.
+		if value, err := strconv.ParseInt(val.Text, 10, 32); err == nil {
+			return int32(value)
+		}
+		if value, err := strconv.ParseInt(val.Text, 10, 64); err == nil {
+			return value
 		}
-		return int32(value)
+		return parseFloat(val.Text)
 
 	case scanner.Float:
-		value, err := strconv.ParseFloat(val.Text, 64)
-		if err != nil {
-			panic(err)
-		}
-		return value
+		return parseFloat(val.Text)
 
 	case scanner.String:
 		value, err := strconv.Unquote(val.Text)
@@ -69,6 +70,17 @@ func (val *PrimitiveValue) Deserialize(vars map[string]interface{}) interface{}
 func (val *PrimitiveValue) String() string            { return val.Text }
 func (val *PrimitiveValue) Location() errors.Location { return val.Loc }
 
+// parseFloat returns the value of a number literal, or the literal as a json.Number if it does
+// not fit into a float64. Such a literal is invalid for Int and Float, but custom scalars may
+// accept it, and packing it into a float64 fails instead of panicking here.
+func parseFloat(text string) interface{} {
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return json.Number(text)
+	}
+	return value
+}
+
 // ListValue represents a literal list Value in the GraphQL specification.
 //
 // http://spec.graphql.org/draft/#sec-List-Value
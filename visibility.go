@@ -0,0 +1,52 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/internal/schema"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// VisibilityFunc reports whether a schema element is visible to the request with the context
+// ctx. The element is identified by its schema coordinate, e.g. "User" for a type, "User.email"
+// for a field, "User.posts(first:)" for an argument, "UserInput.email" for an input field or
+// "Role.ADMIN" for an enum value, and directives are the directives applied to it, so that
+// visibility may be declared in the schema, e.g. with a custom @visibility(role:) directive.
+type VisibilityFunc func(ctx context.Context, coordinate string, directives types.DirectiveList) bool
+
+// Visibility hides schema elements per request. Hidden elements do not exist for the request:
+// queries using them fail validation as if they were not part of the schema, and they are
+// omitted from introspection. Fields, arguments and input fields of hidden types are hidden
+// as well, and so are fields and input types with a hidden required argument or input field.
+// Built-in types and the root operation types are always visible.
+//
+// The visible function is called for every element of the schema on each request. The
+// filtered schemas are cached by the set of hidden elements, so visible should depend on a
+// small number of distinct properties of the request, e.g. the role of the user.
+func Visibility(visible VisibilityFunc) SchemaOpt {
+	return func(s *Schema) {
+		s.visible = visible
+	}
+}
+
+// schemaFor returns the type system visible to the request with the context ctx.
+func (s *Schema) schemaFor(ctx context.Context) *types.Schema {
+	if s.visible == nil {
+		return s.schema
+	}
+
+	hidden := schema.Hidden(s.schema, func(coordinate string, directives types.DirectiveList) bool {
+		return s.visible(ctx, coordinate, directives)
+	})
+	if len(hidden) == 0 {
+		return s.schema
+	}
+
+	key := strings.Join(hidden, " ")
+	if filtered, ok := s.visibleSchemas.Load(key); ok {
+		return filtered.(*types.Schema)
+	}
+	filtered, _ := s.visibleSchemas.LoadOrStore(key, schema.Filter(s.schema, hidden))
+	return filtered.(*types.Schema)
+}
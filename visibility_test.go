@@ -0,0 +1,266 @@
+package graphql_test
+
+import (
+	"context"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+type roleKey struct{}
+
+const visibilitySchema = `
+	directive @visibility(role: String!) on FIELD_DEFINITION | OBJECT | ENUM_VALUE
+
+	type Query {
+		user: User!
+		audit: Audit
+		users(filter: Status, includeDeleted: Boolean @visibility(role: "employee")): [User!]!
+	}
+
+	type User {
+		name: String!
+		salary: Int! @visibility(role: "employee")
+		status: Status!
+	}
+
+	type Audit @visibility(role: "employee") {
+		entries: [String!]!
+	}
+
+	enum Status {
+		ACTIVE
+		SUSPENDED @visibility(role: "employee")
+	}
+`
+
+type visibilityResolver struct{}
+
+func (*visibilityResolver) User() *visibilityUser { return &visibilityUser{} }
+
+func (*visibilityResolver) Audit() *visibilityAudit { return &visibilityAudit{} }
+
+func (*visibilityResolver) Users(args struct {
+	Filter         *string
+	IncludeDeleted *bool
+}) []*visibilityUser {
+	return []*visibilityUser{{}}
+}
+
+type visibilityUser struct{}
+
+func (*visibilityUser) Name() string   { return "Alice" }
+func (*visibilityUser) Salary() int32  { return 100 }
+func (*visibilityUser) Status() string { return "ACTIVE" }
+
+type visibilityAudit struct{}
+
+func (*visibilityAudit) Entries() []string { return []string{"login"} }
+
+func visibleToRole(ctx context.Context, coordinate string, directives types.DirectiveList) bool {
+	d := directives.Get("visibility")
+	if d == nil {
+		return true
+	}
+	role, _ := d.Arguments.Get("role")
+	return role.Deserialize(nil) == ctx.Value(roleKey{})
+}
+
+func TestVisibility(t *testing.T) {
+	s := graphql.MustParseSchema(visibilitySchema, &visibilityResolver{}, graphql.Visibility(visibleToRole))
+	employee := context.WithValue(context.Background(), roleKey{}, "employee")
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: employee,
+			Schema:  s,
+			Query: `
+				{
+					user { name salary status }
+					audit { entries }
+					users(filter: SUSPENDED, includeDeleted: true) { name }
+				}
+			`,
+			ExpectedResult: `
+				{
+					"user": {"name": "Alice", "salary": 100, "status": "ACTIVE"},
+					"audit": {"entries": ["login"]},
+					"users": [{"name": "Alice"}]
+				}
+			`,
+		},
+		{
+			Schema: s,
+			Query: `
+				{
+					user { name salary }
+				}
+			`,
+			ExpectedErrors: []*errors.QueryError{{
+				Message:   `Cannot query field "salary" on type "User".`,
+				Locations: []errors.Location{{Line: 3, Column: 18}},
+				Rule:      "FieldsOnCorrectType",
+			}},
+		},
+		{
+			Schema: s,
+			Query: `
+				{
+					audit { entries }
+				}
+			`,
+			ExpectedErrors: []*errors.QueryError{{
+				Message:   `Cannot query field "audit" on type "Query".`,
+				Locations: []errors.Location{{Line: 3, Column: 6}},
+				Rule:      "FieldsOnCorrectType",
+			}},
+		},
+		{
+			Schema: s,
+			Query: `
+				{
+					users(includeDeleted: true) { name }
+				}
+			`,
+			ExpectedErrors: []*errors.QueryError{{
+				Message:   `Unknown argument "includeDeleted" on field "users" of type "Query".`,
+				Locations: []errors.Location{{Line: 3, Column: 12}},
+				Rule:      "KnownArgumentNames",
+			}},
+		},
+		{
+			Schema: s,
+			Query: `
+				{
+					users(filter: SUSPENDED) { name }
+				}
+			`,
+			ExpectedErrors: []*errors.QueryError{{
+				Message:   "Argument \"filter\" has invalid value SUSPENDED.\nExpected type \"Status\", found SUSPENDED.",
+				Locations: []errors.Location{{Line: 3, Column: 20}},
+				Rule:      "ArgumentsOfCorrectType",
+			}},
+		},
+		{
+			Schema: s,
+			Query: `
+				{
+					__type(name: "User") { fields { name } }
+					audit: __type(name: "Audit") { name }
+					status: __type(name: "Status") { enumValues { name } }
+				}
+			`,
+			ExpectedResult: `
+				{
+					"__type": {"fields": [{"name": "name"}, {"name": "status"}]},
+					"audit": null,
+					"status": {"enumValues": [{"name": "ACTIVE"}]}
+				}
+			`,
+		},
+		{
+			Context: employee,
+			Schema:  s,
+			Query: `
+				{
+					__type(name: "User") { fields { name } }
+				}
+			`,
+			ExpectedResult: `
+				{
+					"__type": {"fields": [{"name": "name"}, {"name": "salary"}, {"name": "status"}]}
+				}
+			`,
+		},
+	})
+}
+
+type requiredArgsResolver struct{}
+
+func (*requiredArgsResolver) Items(args struct{ Secret string }) string { return "ok:" + args.Secret }
+
+func (*requiredArgsResolver) Search(args struct{ Input struct{ Token string } }) string {
+	return "ok:" + args.Input.Token
+}
+
+func (*requiredArgsResolver) Hello() string { return "Hello" }
+
+func TestVisibility_hiddenRequiredValues(t *testing.T) {
+	s := graphql.MustParseSchema(`
+		directive @visibility(role: String!) on ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION
+
+		type Query {
+			items(secret: String! @visibility(role: "employee")): String!
+			search(input: SearchInput!): String!
+			hello: String!
+		}
+
+		input SearchInput {
+			token: String! @visibility(role: "employee")
+		}
+	`, &requiredArgsResolver{}, graphql.Visibility(visibleToRole))
+	employee := context.WithValue(context.Background(), roleKey{}, "employee")
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: employee,
+			Schema:  s,
+			Query: `
+				{
+					items(secret: "a")
+					search(input: {token: "b"})
+				}
+			`,
+			ExpectedResult: `
+				{
+					"items": "ok:a",
+					"search": "ok:b"
+				}
+			`,
+		},
+		{
+			Schema: s,
+			Query: `
+				{
+					items
+				}
+			`,
+			ExpectedErrors: []*errors.QueryError{{
+				Message:   `Cannot query field "items" on type "Query".`,
+				Locations: []errors.Location{{Line: 3, Column: 6}},
+				Rule:      "FieldsOnCorrectType",
+			}},
+		},
+		{
+			Schema: s,
+			Query: `
+				{
+					search(input: {})
+				}
+			`,
+			ExpectedErrors: []*errors.QueryError{{
+				Message:   `Cannot query field "search" on type "Query".`,
+				Locations: []errors.Location{{Line: 3, Column: 6}},
+				Rule:      "FieldsOnCorrectType",
+			}},
+		},
+		{
+			Schema: s,
+			Query: `
+				{
+					__schema { queryType { fields { name } } }
+					input: __type(name: "SearchInput") { name }
+				}
+			`,
+			ExpectedResult: `
+				{
+					"__schema": {"queryType": {"fields": [{"name": "hello"}]}},
+					"input": null
+				}
+			`,
+		},
+	})
+}
@@ -0,0 +1,27 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/internal/exec"
+)
+
+// AddWarning reports a non-fatal problem with the field whose resolver received ctx, e.g. that a
+// value is stale or that a deprecated argument was used. Unlike an error, a warning keeps the value
+// of the field. The warnings of a request are listed with the path and locations of their fields
+// in the "warnings" extension of the response. The warnings of a subscription resolver are sent
+// with its next event. AddWarning does nothing if ctx is not the context of a resolver.
+func AddWarning(ctx context.Context, message string) {
+	if info := exec.FieldInfoFromContext(ctx); info != nil {
+		info.AddWarning(message)
+	}
+}
+
+// warningsExtensions returns the extensions of a response with the given warnings.
+func warningsExtensions(warnings []*errors.QueryError) map[string]interface{} {
+	if len(warnings) == 0 {
+		return nil
+	}
+	return map[string]interface{}{"warnings": warnings}
+}
@@ -0,0 +1,112 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+type warningQuery struct{}
+
+func (*warningQuery) Product() *warningProduct { return &warningProduct{} }
+
+type warningProduct struct{}
+
+func (*warningProduct) Price(ctx context.Context) float64 {
+	graphql.AddWarning(ctx, "price is stale")
+	return 9.99
+}
+
+func TestAddWarning(t *testing.T) {
+	schema := graphql.MustParseSchema(`
+		type Query {
+			product: Product!
+		}
+		type Product {
+			price: Float!
+		}
+	`, &warningQuery{})
+
+	resp := schema.Exec(context.Background(), `
+		{
+			product {
+				price
+			}
+		}
+	`, "", nil)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+	got, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"data":{"product":{"price":9.99}},"extensions":{"warnings":[{"message":"price is stale","locations":[{"line":4,"column":5}],"path":["product","price"]}]}}`
+	if string(got) != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+
+	// Warnings can not be added outside of resolvers.
+	graphql.AddWarning(context.Background(), "ignored")
+}
+
+type warningSubscription struct{}
+
+func (*warningSubscription) Hello() string { return "hello" }
+
+func (*warningSubscription) PriceChanged(ctx context.Context) <-chan *warningProduct {
+	graphql.AddWarning(ctx, "prices are delayed")
+	c := make(chan *warningProduct, 2)
+	c <- &warningProduct{}
+	c <- &warningProduct{}
+	close(c)
+	return c
+}
+
+func TestAddWarning_subscription(t *testing.T) {
+	schema := graphql.MustParseSchema(`
+		type Query {
+			hello: String!
+		}
+		type Subscription {
+			priceChanged: Product!
+		}
+		type Product {
+			price: Float!
+		}
+	`, &warningSubscription{})
+
+	c, err := schema.Subscribe(context.Background(), `
+		subscription {
+			priceChanged {
+				price
+			}
+		}
+	`, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for resp := range c {
+		b, err := json.Marshal(resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(b))
+	}
+	// The warning of the subscription resolver is sent with the first event only.
+	want := []string{
+		`{"data":{"priceChanged":{"price":9.99}},"extensions":{"warnings":[{"message":"prices are delayed","locations":[{"line":3,"column":4}],"path":["priceChanged"]},{"message":"price is stale","locations":[{"line":4,"column":5}],"path":["priceChanged","price"]}]}}`,
+		`{"data":{"priceChanged":{"price":9.99}},"extensions":{"warnings":[{"message":"price is stale","locations":[{"line":4,"column":5}],"path":["priceChanged","price"]}]}}`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want %d responses, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("response %d: want %s, got %s", i, want[i], got[i])
+		}
+	}
+}